@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package forwarder
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/connections"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mirror"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/trace"
+)
+
+const (
+	debugReadHeaderTimeout = 10 * time.Second
+	debugUnixScheme        = "unix://"
+)
+
+// startDebugServer serves the debug endpoints on listenAddr until ctx is done:
+// /debug/capture - pcap capture of a connection, see mirror.NewCaptureHandler
+// /debug/trace - ofproto/trace of a connection, see trace.NewHandler
+func startDebugServer(ctx context.Context, listenAddr string, mirrors *mirror.Mirrors, tracer *trace.Tracer) error {
+	listener, err := listenDebug(listenAddr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/debug/capture", mirror.NewCaptureHandler(mirrors))
+	mux.Handle("/debug/trace", trace.NewHandler(tracer))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: debugReadHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		if serveErr := server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			log.FromContext(ctx).Errorf("debug endpoint %s failed: %v", listenAddr, serveErr)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	log.FromContext(ctx).Infof("serving debug endpoint on %s", listener.Addr())
+	return nil
+}
+
+// serveDebug serves the debug endpoints of the connections registered in registry when a debug endpoint is set, see
+// WithDebugEndpoint
+func serveDebug(ctx context.Context, opts *forwarderOptions, registry *connections.Registry, mirrors *mirror.Mirrors) error {
+	if opts.debugListenAddr == "" {
+		return nil
+	}
+	tracer := opts.tracer
+	if tracer == nil {
+		tracer = trace.NewTracer(opts.bridgeName, registry)
+	}
+	return startDebugServer(ctx, opts.debugListenAddr, mirrors, tracer)
+}
+
+// listenDebug listens on the unix socket of a "unix://<path>" listenAddr, otherwise on the tcp listenAddr which
+// must be a loopback address as the debug endpoints are not authenticated
+func listenDebug(listenAddr string) (net.Listener, error) {
+	network, address := "unix", strings.TrimPrefix(listenAddr, debugUnixScheme)
+	if address == listenAddr {
+		host, _, err := net.SplitHostPort(listenAddr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid debug endpoint %s", listenAddr)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, errors.Errorf("debug endpoint %s is not a loopback address nor a unix socket", listenAddr)
+		}
+		network = "tcp"
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on debug endpoint %s", listenAddr)
+	}
+	return listener, nil
+}
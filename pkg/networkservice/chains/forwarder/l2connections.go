@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package forwarder
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/l2config"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// configureL2Connections configures ovs with the l2 connection points given by the caller and the ones of the
+// config file, and returns them in a registry kept up to date from the config file changes.
+func configureL2Connections(ctx context.Context, opts *forwarderOptions,
+	l2Connections map[string]*ovsutil.L2ConnectionPoint) (*genericsync.Map[string, *ovsutil.L2ConnectionPoint], error) {
+	allL2Connections := make(map[string]*ovsutil.L2ConnectionPoint)
+	for selector, l2Point := range l2Connections {
+		allL2Connections[selector] = l2Point
+	}
+	var fileL2Connections map[string]*ovsutil.L2ConnectionPoint
+	if opts.l2ConfigFile != "" {
+		var err error
+		if fileL2Connections, err = l2config.ReadFile(opts.l2ConfigFile); err != nil {
+			return nil, err
+		}
+		for selector, l2Point := range fileL2Connections {
+			allL2Connections[selector] = l2Point
+		}
+	}
+
+	if err := ovsutil.ConfigureOvS(ctx, allL2Connections, opts.bridgeName,
+		ovsutil.WithUplinkStateDir(opts.uplinkStateDir), ovsutil.WithFlowExport(opts.flowExport)); err != nil {
+		return nil, err
+	}
+	if wg := opts.uplinkRestoreWaitGroup; wg != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			restoreCtx := log.WithLog(context.Background(), log.FromContext(ctx))
+			if err := ovsutil.RestoreUplinks(restoreCtx, opts.uplinkStateDir); err != nil {
+				log.FromContext(ctx).Errorf("failed to restore uplinks: %v", err)
+			}
+		}()
+	}
+	if opts.vlanPatchPorts {
+		if err := ovsutil.ConfigurePatchPorts(ctx, allL2Connections, opts.bridgeName); err != nil {
+			return nil, err
+		}
+	}
+
+	l2ConnectionPoints := ovsutil.NewL2ConnectionRegistry(allL2Connections)
+	if opts.l2ConfigFile != "" {
+		l2config.Watch(ctx, opts.l2ConfigFile, fileL2Connections, l2ConnectionPoints,
+			func(ctx context.Context, l2Point *ovsutil.L2ConnectionPoint) error {
+				if err := ovsutil.ConfigureL2ConnectionPoint(ctx, l2Point, opts.uplinkStateDir); err != nil {
+					return err
+				}
+				if opts.vlanPatchPorts {
+					return ovsutil.ConfigurePatchPort(ctx, l2Point, opts.bridgeName)
+				}
+				return nil
+			})
+	}
+	return l2ConnectionPoints, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package forwarder
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	vxlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+	sriovtokens "github.com/networkservicemesh/sdk-sriov/pkg/tools/tokens"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/switchcase"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vhostuser"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vlan/mtu"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vxlan"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// newMechanisms returns the mechanism servers by mechanism type and the mechanism clients of the forwarder, the
+// servers and the clients share the ports of the connections, e.g. the vxlan tunnel ports
+func newMechanisms(ctx context.Context, opts *forwarderOptions, tunnelIP net.IP,
+	l2ConnectionPoints *genericsync.Map[string, *ovsutil.L2ConnectionPoint],
+) (map[string]networkservice.NetworkServiceServer, []networkservice.NetworkServiceClient, error) {
+	parentIfMutex := &sync.Mutex{}
+	parentIfRefCount := make(map[string]int)
+
+	vxlanInterfacesMutex := &sync.Mutex{}
+	vxlanInterfaces := make(map[string]int)
+
+	mechanismServers := map[string]networkservice.NetworkServiceServer{
+		kernelmech.MECHANISM: switchcase.NewServer(
+			&switchcase.ServerCase{
+				Condition: func(_ context.Context, conn *networkservice.Connection) bool {
+					return sriovtokens.IsTokenID(kernelmech.ToMechanism(conn.GetMechanism()).GetDeviceTokenID())
+				},
+				Server: chain.NewNetworkServiceServer(
+					opts.resourcePoolServer,
+					opts.hwOffloadServer,
+					kernel.NewSmartVFServer(opts.bridgeName, parentIfMutex, parentIfRefCount),
+				),
+			},
+			&switchcase.ServerCase{
+				Condition: switchcase.Default,
+				Server:    kernel.NewVethServer(opts.bridgeName, parentIfMutex, parentIfRefCount),
+			},
+		),
+		vxlanmech.MECHANISM: vxlan.NewServer(tunnelIP, opts.bridgeName, vxlanInterfacesMutex, vxlanInterfaces, opts.vxlanOpts...),
+	}
+	vlanOpts := opts.vlanOpts
+	vlanOpts = append(vlanOpts, vlan.WithChainContext(ctx), vlan.WithL2ConnectionRegistry(l2ConnectionPoints))
+	if opts.vlanPatchPorts {
+		vlanOpts = append(vlanOpts, vlan.WithPatchPorts())
+	}
+	if opts.mtuReadvertise {
+		vlanOpts = append(vlanOpts, vlan.WithMTUOptions(mtu.WithReadvertise()))
+	}
+	vhostUserClient := null.NewClient()
+	if opts.vhostUserSocketDir != "" {
+		vhostUserBridge, err := vhostuser.NewBridge(ctx, opts.vhostUserBridgeName, opts.bridgeName)
+		if err != nil {
+			return nil, nil, err
+		}
+		mechanismServers[vhostuser.MECHANISM] = vhostuser.NewServer(vhostUserBridge, opts.vhostUserSocketDir)
+		vhostUserClient = vhostuser.NewClient(vhostUserBridge, opts.vhostUserSocketDir)
+	}
+
+	mechanismClients := []networkservice.NetworkServiceClient{
+		kernel.NewClient(opts.bridgeName, parentIfMutex, parentIfRefCount),
+		vhostUserClient,
+		opts.resourcePoolClient,
+		vxlan.NewClient(tunnelIP, opts.bridgeName, vxlanInterfacesMutex, vxlanInterfaces, opts.vxlanOpts...),
+		vlan.NewClient(opts.bridgeName, nil, vlanOpts...),
+	}
+	return mechanismServers, mechanismClients, nil
+}
@@ -21,13 +21,22 @@ package forwarder
 
 import (
 	"net/url"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/connections"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/l2ovsconnect"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mirror"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/qos"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/stats"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/trace"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
 )
 
 type forwarderOptions struct {
@@ -37,10 +46,33 @@ type forwarderOptions struct {
 	authorizeMonitorConnectionServer networkservice.MonitorConnectionServer
 	resourcePoolServer               networkservice.NetworkServiceServer
 	resourcePoolClient               networkservice.NetworkServiceClient
+	hwOffloadServer                  networkservice.NetworkServiceServer
+	hwOffloadClient                  networkservice.NetworkServiceClient
 	clientURL                        *url.URL
 	dialTimeout                      time.Duration
 	vxlanOpts                        []vxlan.Option
+	l2ConnectOpts                    []l2ovsconnect.Option
+	stats                            bool
+	statsOpts                        []stats.Option
+	vlanPatchPorts                   bool
+	vlanOpts                         []vlan.Option
+	mtuReadvertise                   bool
+	l2ConfigFile                     string
+	uplinkStateDir                   string
+	uplinkRestoreWaitGroup           *sync.WaitGroup
 	dialOpts                         []grpc.DialOption
+	vhostUserSocketDir               string
+	vhostUserBridgeName              string
+	linkState                        bool
+	qos                              bool
+	qosPolicies                      map[string]*qos.Policy
+	connections                      *connections.Registry
+	mirrors                          *mirror.Mirrors
+	mirroring                        bool
+	mirrorOpts                       []mirror.Option
+	tracer                           *trace.Tracer
+	debugListenAddr                  string
+	flowExport                       *ovsutil.FlowExportConfig
 }
 
 // Option is an option pattern for forwarder chain elements
@@ -100,6 +132,14 @@ func WithResourcePoolClient(resourcePoolClient networkservice.NetworkServiceClie
 	}
 }
 
+// withHwOffload sets hw offload reporting chain elements
+func withHwOffload(hwOffloadServer networkservice.NetworkServiceServer, hwOffloadClient networkservice.NetworkServiceClient) Option {
+	return func(o *forwarderOptions) {
+		o.hwOffloadServer = hwOffloadServer
+		o.hwOffloadClient = hwOffloadClient
+	}
+}
+
 // WithClientURL sets clientURL.
 func WithClientURL(clientURL *url.URL) Option {
 	return func(c *forwarderOptions) {
@@ -121,9 +161,153 @@ func WithVxlanOptions(opts ...vxlan.Option) Option {
 	}
 }
 
+// WithL2ConnectOptions sets l2 connect options
+func WithL2ConnectOptions(opts ...l2ovsconnect.Option) Option {
+	return func(o *forwarderOptions) {
+		o.l2ConnectOpts = opts
+	}
+}
+
+// WithStats enables the ovs traffic statistics of the connections in their path segment metrics
+func WithStats(opts ...stats.Option) Option {
+	return func(o *forwarderOptions) {
+		o.stats = true
+		o.statsOpts = opts
+	}
+}
+
+// WithLinkState enables reporting the connections down when their ports go down
+func WithLinkState() Option {
+	return func(o *forwarderOptions) {
+		o.linkState = true
+	}
+}
+
+// WithVlanPatchPorts links br-nsm to the l2 bridges with patch ports and makes the VLAN breakout with
+// flows on br-nsm instead of moving the client ports to the l2 bridges
+func WithVlanPatchPorts() Option {
+	return func(o *forwarderOptions) {
+		o.vlanPatchPorts = true
+	}
+}
+
+// WithVlanOptions sets vlan option
+func WithVlanOptions(opts ...vlan.Option) Option {
+	return func(o *forwarderOptions) {
+		o.vlanOpts = opts
+	}
+}
+
+// WithMTUReadvertise makes the established vlan connections take over the current uplink MTU at their next refresh,
+// see mtu.WithReadvertise
+func WithMTUReadvertise() Option {
+	return func(o *forwarderOptions) {
+		o.mtuReadvertise = true
+	}
+}
+
+// WithL2ConfigFile sets the YAML/JSON config file of the l2 connection points, its connection points are added to
+// the ones given to the forwarder and override them on selector conflict. The changes of the file are applied at runtime.
+func WithL2ConfigFile(configFile string) Option {
+	return func(o *forwarderOptions) {
+		o.l2ConfigFile = configFile
+	}
+}
+
+// WithUplinkStateDir sets the directory where the original configuration of the uplinks taken over by the l2
+// bridges is persisted, it is not persisted by default. The directory must outlive the forwarder container, e.g. be a
+// hostPath volume, so that a restarted forwarder can restore the uplinks taken over by the previous one.
+func WithUplinkStateDir(stateDir string) Option {
+	return func(o *forwarderOptions) {
+		o.uplinkStateDir = stateDir
+	}
+}
+
+// WithUplinkRestoreOnShutdown restores the original configuration of the uplinks when the forwarder context is done,
+// the restore is added to wg so that the caller can wait for it before exiting. It needs the uplink configuration to
+// be persisted, see WithUplinkStateDir.
+func WithUplinkRestoreOnShutdown(wg *sync.WaitGroup) Option {
+	return func(o *forwarderOptions) {
+		o.uplinkRestoreWaitGroup = wg
+	}
+}
+
 // WithDialOptions sets dial options
 func WithDialOptions(opts ...grpc.DialOption) Option {
 	return func(o *forwarderOptions) {
 		o.dialOpts = opts
 	}
 }
+
+// WithVhostUser enables vhost-user mechanism, the vhost-user ports are created on a dedicated netdev bridge
+// linked to the ovs bridge, see WithVhostUserBridge, and their sockets are placed under socketDir
+func WithVhostUser(socketDir string) Option {
+	return func(o *forwarderOptions) {
+		o.vhostUserSocketDir = socketDir
+	}
+}
+
+// WithVhostUserBridge sets the name of the netdev bridge of the vhost-user ports
+func WithVhostUserBridge(bridgeName string) Option {
+	return func(o *forwarderOptions) {
+		o.vhostUserBridgeName = bridgeName
+	}
+}
+
+// WithQoS enables the bandwidth limits of the connections set by their labels, policies sets the default limits per
+// network service, which can be overridden by the connection labels
+func WithQoS(policies map[string]*qos.Policy) Option {
+	return func(o *forwarderOptions) {
+		o.qos = true
+		o.qosPolicies = policies
+	}
+}
+
+// WithConnections sets the registry the forwarder registers its connections in. The Mirrors and the Tracer set
+// by WithMirrors and WithTracer must be created on it for the forwarder bridge.
+func WithConnections(registry *connections.Registry) Option {
+	return func(o *forwarderOptions) {
+		o.connections = registry
+	}
+}
+
+// WithMirrors sets the Mirrors of the forwarder connections, see WithConnections
+func WithMirrors(mirrors *mirror.Mirrors) Option {
+	return func(o *forwarderOptions) {
+		o.mirrors = mirrors
+	}
+}
+
+// WithMirroring enables mirroring the connections by their labels, opts are the options of the Mirrors created by
+// the forwarder when WithMirrors is not set, e.g. the mirror targets allowed for the connection labels
+func WithMirroring(opts ...mirror.Option) Option {
+	return func(o *forwarderOptions) {
+		o.mirroring = true
+		o.mirrorOpts = opts
+	}
+}
+
+// WithTracer sets the Tracer of the forwarder connections, see WithConnections
+func WithTracer(tracer *trace.Tracer) Option {
+	return func(o *forwarderOptions) {
+		o.tracer = tracer
+	}
+}
+
+// WithDebugEndpoint serves the debug http endpoints, e.g. the pcap capture of a connection on /debug/capture,
+// the ofproto/trace of a connection on /debug/trace, on listenAddr. The endpoints are not authenticated, so
+// listenAddr must be a loopback address, e.g. "127.0.0.1:8081", or a unix socket, e.g. "unix:///run/nsm/debug.sock".
+func WithDebugEndpoint(listenAddr string) Option {
+	return func(o *forwarderOptions) {
+		o.debugListenAddr = listenAddr
+	}
+}
+
+// WithFlowExport configures IPFIX or sFlow export of the forwarder bridge towards the collectors. With IPFIX the
+// connections are also sampled per flow, the samples carry the observation point ID of the connection, see
+// ovsutil.ObservationPointID, which is published in the path segment metrics of the connection, see WithStats.
+func WithFlowExport(config *ovsutil.FlowExportConfig) Option {
+	return func(o *forwarderOptions) {
+		o.flowExport = config
+	}
+}
@@ -31,12 +31,9 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	kernelmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
-	vxlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/inject"
 	"github.com/networkservicemesh/sdk-sriov/pkg/networkservice/common/resourcepool"
-	sriovtokens "github.com/networkservicemesh/sdk-sriov/pkg/tools/tokens"
 	registryclient "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
 	registryrecvfd "github.com/networkservicemesh/sdk/pkg/registry/common/recvfd"
 
@@ -54,32 +51,50 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/mechanismtranslation"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/null"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/common/roundrobin"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/common/switchcase"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	authmonitor "github.com/networkservicemesh/sdk/pkg/tools/monitorconnection/authorize"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/connections"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/hwoffload"
 	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/l2ovsconnect"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/linkstate"
 	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/kernel"
-	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vlan"
-	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vxlan"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mirror"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/qos"
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/stats"
 	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
 )
 
+const (
+	defaultVhostUserBridge = "br-nsm-vhu"
+)
+
 type ovsConnectNSServer struct {
 	endpoint.Endpoint
 }
 
-// NewSriovServer - returns sriov implementation of the ovsconnectns network service
+// NewSriovServer - returns sriov implementation of the ovsconnectns network service. Besides the VFs, the
+// resource pool hands out the scalable functions listed in sriovConfig, see kernel.NewSFPCIPool
 func NewSriovServer(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnelIPCidr net.IP,
 	pciPool resourcepool.PCIPool, resourcePool resourcepool.ResourcePool, sriovConfig *config.Config,
 	l2Connections map[string]*ovsutil.L2ConnectionPoint, options ...Option,
 ) (endpoint.Endpoint, error) {
 	resourceLock := &sync.Mutex{}
+	// scalable functions are handed out from the resource pool like VFs
+	pciPool = kernel.NewSFPCIPool(pciPool)
 	resourcePoolClient := resourcepool.NewClient(sriov.KernelDriver, resourceLock, pciPool, resourcePool, sriovConfig)
 	resourcePoolServer := resourcepool.NewServer(sriov.KernelDriver, resourceLock, pciPool, resourcePool, sriovConfig)
-	options = append(options, WithResourcePoolServer(resourcePoolServer), WithResourcePoolClient(resourcePoolClient))
+	ovsutil.InitOvsExec(ctx)
+	hwOffloadEnabled, err := ovsutil.IsHwOffloadEnabled()
+	if err != nil {
+		log.FromContext(ctx).Warnf("failed to check hw-offload config: %v", err)
+	} else if !hwOffloadEnabled {
+		log.FromContext(ctx).Warn("hw-offload is not enabled in ovs, smart VF connections won't be offloaded")
+	}
+	options = append(options, WithResourcePoolServer(resourcePoolServer), WithResourcePoolClient(resourcePoolClient),
+		withHwOffload(hwoffload.NewServer(ctx, hwOffloadEnabled), hwoffload.NewClient(ctx, hwOffloadEnabled)))
 
 	return newEndPoint(ctx, tokenGenerator, tunnelIPCidr, l2Connections, options...)
 }
@@ -94,8 +109,11 @@ func newEndPoint(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnel
 		authorizeMonitorConnectionServer: authmonitor.NewMonitorConnectionServer(authmonitor.Any()),
 		resourcePoolServer:               null.NewServer(),
 		resourcePoolClient:               null.NewClient(),
+		hwOffloadServer:                  null.NewServer(),
+		hwOffloadClient:                  null.NewClient(),
 		clientURL:                        &url.URL{Scheme: "unix", Host: "connect.to.socket"},
 		dialTimeout:                      time.Millisecond * 200,
+		vhostUserBridgeName:              defaultVhostUserBridge,
 	}
 	for _, opt := range options {
 		opt(opts)
@@ -104,17 +122,22 @@ func newEndPoint(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnel
 	if err != nil {
 		return nil, err
 	}
-	err = ovsutil.ConfigureOvS(ctx, l2Connections, opts.bridgeName)
+	l2ConnectionPoints, err := configureL2Connections(ctx, opts, l2Connections)
 	if err != nil {
 		return nil, err
 	}
-
-	parentIfMutex := &sync.Mutex{}
-	parentIfRefCount := make(map[string]int)
-
-	vxlanInterfacesMutex := &sync.Mutex{}
-	vxlanInterfaces := make(map[string]int)
-	rv := &ovsConnectNSServer{}
+	mechanismServers, mechanismClients, err := newMechanisms(ctx, opts, tunnelIP, l2ConnectionPoints)
+	if err != nil {
+		return nil, err
+	}
+	featureServers, err := newFeatureServers(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	l2ConnectOpts := opts.l2ConnectOpts
+	if opts.flowExport != nil && opts.flowExport.Protocol == ovsutil.FlowExportIPFIX && opts.flowExport.FlowSampling > 0 {
+		l2ConnectOpts = append(l2ConnectOpts, l2ovsconnect.WithFlowSampling(opts.flowExport.FlowSampling, opts.flowExport.ObsDomainID))
+	}
 
 	nseClient := registryclient.NewNetworkServiceEndpointRegistryClient(ctx,
 		registryclient.WithClientURL(opts.clientURL),
@@ -125,30 +148,30 @@ func newEndPoint(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnel
 		registryclient.WithClientURL(opts.clientURL),
 		registryclient.WithDialOptions(opts.dialOpts...))
 
+	clientFunctionality := []networkservice.NetworkServiceClient{
+		mechanismtranslation.NewClient(),
+		l2ovsconnect.NewClient(opts.bridgeName, l2ConnectOpts...),
+		connectioncontextkernel.NewClient(),
+		inject.NewClient(),
+		opts.hwOffloadClient,
+	}
+	clientFunctionality = append(clientFunctionality, mechanismClients...)
+	clientFunctionality = append(clientFunctionality,
+		filtermechanisms.NewClient(),
+		recvfd.NewClient(),
+		sendfd.NewClient(),
+	)
+
 	additionalFunctionality := []networkservice.NetworkServiceServer{
 		metadata.NewServer(),
 		recvfd.NewServer(),
 		sendfd.NewServer(),
 		discover.NewServer(nsClient, nseClient),
 		roundrobin.NewServer(),
-		mechanisms.NewServer(map[string]networkservice.NetworkServiceServer{
-			kernelmech.MECHANISM: switchcase.NewServer(
-				&switchcase.ServerCase{
-					Condition: func(_ context.Context, conn *networkservice.Connection) bool {
-						return sriovtokens.IsTokenID(kernelmech.ToMechanism(conn.GetMechanism()).GetDeviceTokenID())
-					},
-					Server: chain.NewNetworkServiceServer(
-						opts.resourcePoolServer,
-						kernel.NewSmartVFServer(opts.bridgeName, parentIfMutex, parentIfRefCount),
-					),
-				},
-				&switchcase.ServerCase{
-					Condition: switchcase.Default,
-					Server:    kernel.NewVethServer(opts.bridgeName, parentIfMutex, parentIfRefCount),
-				},
-			),
-			vxlanmech.MECHANISM: vxlan.NewServer(tunnelIP, opts.bridgeName, vxlanInterfacesMutex, vxlanInterfaces, opts.vxlanOpts...),
-		}),
+		mechanisms.NewServer(mechanismServers),
+	}
+	additionalFunctionality = append(additionalFunctionality, featureServers...)
+	additionalFunctionality = append(additionalFunctionality,
 		inject.NewServer(),
 		connectioncontextkernel.NewServer(),
 		connect.NewServer(
@@ -157,24 +180,12 @@ func newEndPoint(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnel
 				client.WithName(opts.name),
 				client.WithDialOptions(opts.dialOpts...),
 				client.WithDialTimeout(opts.dialTimeout),
-				client.WithAdditionalFunctionality(
-					mechanismtranslation.NewClient(),
-					l2ovsconnect.NewClient(opts.bridgeName),
-					connectioncontextkernel.NewClient(),
-					inject.NewClient(),
-					// mechanisms
-					kernel.NewClient(opts.bridgeName, parentIfMutex, parentIfRefCount),
-					opts.resourcePoolClient,
-					vxlan.NewClient(tunnelIP, opts.bridgeName, vxlanInterfacesMutex, vxlanInterfaces, opts.vxlanOpts...),
-					vlan.NewClient(opts.bridgeName, l2Connections),
-					filtermechanisms.NewClient(),
-					recvfd.NewClient(),
-					sendfd.NewClient(),
-				),
+				client.WithAdditionalFunctionality(clientFunctionality...),
 			),
 		),
-	}
+	)
 
+	rv := &ovsConnectNSServer{}
 	rv.Endpoint = endpoint.NewServer(ctx, tokenGenerator,
 		endpoint.WithName(opts.name),
 		endpoint.WithAuthorizeServer(opts.authorizeServer),
@@ -184,6 +195,37 @@ func newEndPoint(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnel
 	return rv, nil
 }
 
+// newFeatureServers returns the chain elements of the optional connection features, e.g. qos, statistics, link state
+// and mirroring, followed by the one registering the connections observed by the mirrors and the debug endpoints
+func newFeatureServers(ctx context.Context, opts *forwarderOptions) ([]networkservice.NetworkServiceServer, error) {
+	connectionRegistry := opts.connections
+	if connectionRegistry == nil {
+		connectionRegistry = connections.NewRegistry()
+	}
+	mirrors := opts.mirrors
+	if mirrors == nil {
+		mirrors = mirror.NewMirrors(opts.bridgeName, connectionRegistry, opts.mirrorOpts...)
+	}
+	if err := serveDebug(ctx, opts, connectionRegistry, mirrors); err != nil {
+		return nil, err
+	}
+
+	var servers []networkservice.NetworkServiceServer
+	if opts.qos {
+		servers = append(servers, qos.NewServer(opts.qosPolicies))
+	}
+	if opts.stats {
+		servers = append(servers, stats.NewServer(ctx, opts.bridgeName, opts.statsOpts...))
+	}
+	if opts.linkState {
+		servers = append(servers, linkstate.NewServer(ctx))
+	}
+	if opts.mirroring {
+		servers = append(servers, mirror.NewServer(mirrors))
+	}
+	return append(servers, connections.NewServer(connectionRegistry)), nil
+}
+
 // NewKernelServer - returns kernel implementation of the ovsconnectns network service
 func NewKernelServer(ctx context.Context, tokenGenerator token.GeneratorFunc, tunnelIPCidr net.IP,
 	l2Connections map[string]*ovsutil.L2ConnectionPoint, options ...Option,
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+// Connection is the snapshot of a connection of the forwarder taken when it is requested
+type Connection struct {
+	Conn *networkservice.Connection
+	// ServerPort is the ovs port towards the nsc
+	ServerPort ifnames.OvsPortInfo
+	// ClientPort is the ovs port towards the endpoint, nil if the connection has none
+	ClientPort *ifnames.OvsPortInfo
+}
+
+// Registry keeps the connections of the forwarder, so that the troubleshooting tools, e.g. the mirrors and the
+// tracer, can look them up by ID
+type Registry struct {
+	mutex       sync.Mutex
+	connections map[string]*Connection
+}
+
+// NewRegistry returns an empty Registry, the connections are registered by the server chain element
+func NewRegistry() *Registry {
+	return &Registry{connections: make(map[string]*Connection)}
+}
+
+// Load returns the connection registered with the ID
+func (r *Registry) Load(connID string) (*Connection, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	c, ok := r.connections[connID]
+	return c, ok
+}
+
+func (r *Registry) store(conn *networkservice.Connection, serverPort, clientPort *ifnames.OvsPortInfo) {
+	c := &Connection{Conn: conn.Clone(), ServerPort: *serverPort}
+	if clientPort != nil {
+		port := *clientPort
+		c.ClientPort = &port
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.connections[conn.GetId()] = c
+}
+
+func (r *Registry) delete(connID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.connections, connID)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connections provides chain element which registers the connections of the forwarder with their ovs
+// ports in a Registry until they are closed
+package connections
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+type connectionsServer struct {
+	registry *Registry
+}
+
+// NewServer - returns a server chain element registering the connections in registry. It must follow the chain
+// elements looking the connections up in the registry, so that the connection is registered when they get it.
+func NewServer(registry *Registry) networkservice.NetworkServiceServer {
+	return &connectionsServer{registry: registry}
+}
+
+func (s *connectionsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if serverPort, ok := ifnames.Load(ctx, false); ok {
+		clientPort, _ := ifnames.Load(ctx, true)
+		s.registry.store(conn, serverPort, clientPort)
+	}
+	return conn, nil
+}
+
+func (s *connectionsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.registry.delete(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
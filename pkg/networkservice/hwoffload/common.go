@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package hwoffload
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	// StatusKey - mechanism parameter key carrying the hw offload status of the connection
+	StatusKey = "hwOffload"
+
+	// checkInterval - interval of the hw offload status checks of the open connections, the datapath flows
+	// are created and offloaded with the traffic, after the connection is established
+	checkInterval = 10 * time.Second
+)
+
+// offloadMonitor checks the hw offload status of the VF representor based connections periodically until they are
+// closed. The last status of a connection is reported in its mechanism parameters on each (refresh) Request.
+type offloadMonitor struct {
+	chainCtx         context.Context
+	hwOffloadEnabled bool
+	mutex            sync.Mutex
+	connections      map[string]*offloadStatus
+}
+
+type offloadStatus struct {
+	status string
+	cancel context.CancelFunc
+}
+
+func newOffloadMonitor(chainCtx context.Context, hwOffloadEnabled bool) *offloadMonitor {
+	return &offloadMonitor{
+		chainCtx:         chainCtx,
+		hwOffloadEnabled: hwOffloadEnabled,
+		connections:      make(map[string]*offloadStatus),
+	}
+}
+
+// report checks the hw offload status of the connection, sets it in the mechanism parameters and starts the
+// periodic checks of the connection
+func (m *offloadMonitor) report(ctx context.Context, logger log.Logger, conn *networkservice.Connection, isClient bool) {
+	var mechanism *kernel.Mechanism
+	if mechanism = kernel.ToMechanism(conn.GetMechanism()); mechanism == nil {
+		return
+	}
+	ovsPortInfo, ok := ifnames.Load(ctx, isClient)
+	if !ok || !ovsPortInfo.IsVfRepresentor {
+		return
+	}
+	status := m.getStatus(logger, ovsPortInfo.PortName)
+	m.update(logger, conn.GetId(), ovsPortInfo.PortName, status, true)
+	mechanism.GetParameters()[StatusKey] = status
+
+	if !m.hwOffloadEnabled {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	connStatus, ok := m.connections[conn.GetId()]
+	if !ok || connStatus.cancel != nil {
+		return
+	}
+	// the checks outlive the request, they run until the connection is closed or the chain is done
+	checkCtx, cancel := context.WithCancel(m.chainCtx)
+	connStatus.cancel = cancel
+	go m.checkPeriodically(checkCtx, conn.GetId(), ovsPortInfo.PortName)
+}
+
+func (m *offloadMonitor) checkPeriodically(ctx context.Context, connID, portName string) {
+	logger := log.FromContext(ctx).WithField("offloadMonitor", "check")
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// the connection may have been closed while its status was being checked
+			if !m.update(logger, connID, portName, m.getStatus(logger, portName), false) {
+				return
+			}
+		}
+	}
+}
+
+// getStatus returns the hw offload status of the port
+func (m *offloadMonitor) getStatus(logger log.Logger, portName string) string {
+	if !m.hwOffloadEnabled {
+		return ovsutil.OffloadStatusDisabled
+	}
+	status, err := ovsutil.GetOffloadStatus(portName)
+	if err != nil {
+		logger.Warnf("failed to get hw offload status for port %s: %v", portName, err)
+	}
+	return status
+}
+
+// update sets the status of the connection and logs it when it changes. An unknown connection is added when add is
+// set, otherwise update returns false.
+func (m *offloadMonitor) update(logger log.Logger, connID, portName, status string, add bool) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	connStatus, ok := m.connections[connID]
+	if !ok {
+		if !add {
+			return false
+		}
+		connStatus = &offloadStatus{}
+		m.connections[connID] = connStatus
+	}
+	if connStatus.status != status {
+		logger.Infof("hw offload status of connection %s on port %s: %s", connID, portName, status)
+		connStatus.status = status
+	}
+	return true
+}
+
+// stop stops the periodic checks of the connection
+func (m *offloadMonitor) stop(connID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if connStatus, ok := m.connections[connID]; ok {
+		if connStatus.cancel != nil {
+			connStatus.cancel()
+		}
+		delete(m.connections, connID)
+	}
+}
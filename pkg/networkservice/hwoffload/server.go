@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package hwoffload
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+type hwOffloadServer struct {
+	monitor *offloadMonitor
+}
+
+// NewServer - returns a server chain element reporting hw offload status of VF representor based connections,
+// the status is checked periodically until the connection is closed
+func NewServer(chainCtx context.Context, hwOffloadEnabled bool) networkservice.NetworkServiceServer {
+	return &hwOffloadServer{monitor: newOffloadMonitor(chainCtx, hwOffloadEnabled)}
+}
+
+func (h *hwOffloadServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("hwOffloadServer", "Request")
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	h.monitor.report(ctx, logger, conn, metadata.IsClient(h))
+
+	return conn, nil
+}
+
+func (h *hwOffloadServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	h.monitor.stop(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	// ACLToEndpointLabel - comma separated allow-list of the connections the nsc may open towards the endpoint,
+	// an entry is <protocol>[:<port>[-<port>]][@<destination cidr>], e.g. "tcp:443,udp:5000-5010@10.0.0.0/24,icmp".
+	// Protocols are ip, tcp, udp, sctp, icmp and icmp6.
+	ACLToEndpointLabel = "acl.to-endpoint"
+	// ACLToClientLabel - comma separated allow-list of the connections the endpoint may open towards the nsc,
+	// same format as ACLToEndpointLabel
+	ACLToClientLabel = "acl.to-client"
+)
+
+const maxCtZone = 65535
+
+// aclProtocols maps the acl protocols to the ovs protocol of each address family
+var aclProtocols = map[string][2]string{
+	"ip":    {"ip", "ipv6"},
+	"tcp":   {"tcp", "tcp6"},
+	"udp":   {"udp", "udp6"},
+	"sctp":  {"sctp", "sctp6"},
+	"icmp":  {"icmp", ""},
+	"icmp6": {"", "icmp6"},
+}
+
+// aclDirection contains the openflow matches of the new connections allowed in a direction of the cross connect,
+// all new connections are allowed when the direction is not restricted
+type aclDirection struct {
+	restricted bool
+	matches    []string
+}
+
+// connACL is the stateful acl of a connection, each connection has its own conntrack zone
+type connACL struct {
+	zone       uint32
+	toEndpoint aclDirection
+	toClient   aclDirection
+}
+
+// getConnACL returns the acl carried in the connection labels, nil when the connection has no acl label
+func getConnACL(conn *networkservice.Connection) (*connACL, error) {
+	acl := &connACL{}
+	for label, direction := range map[string]*aclDirection{ACLToEndpointLabel: &acl.toEndpoint, ACLToClientLabel: &acl.toClient} {
+		value, ok := conn.GetLabels()[label]
+		if !ok {
+			continue
+		}
+		direction.restricted = true
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			matches, err := parseACLEntry(entry)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value %q of label %s", value, label)
+			}
+			direction.matches = append(direction.matches, matches...)
+		}
+	}
+	if !acl.toEndpoint.restricted && !acl.toClient.restricted {
+		return nil, nil
+	}
+	return acl, nil
+}
+
+// parseACLEntry returns the openflow matches of an acl entry, one per address family and port mask
+func parseACLEntry(entry string) ([]string, error) {
+	spec, cidr, hasCidr := strings.Cut(entry, "@")
+	protocol, portRange, hasPorts := strings.Cut(spec, ":")
+	protocols, ok := aclProtocols[protocol]
+	if !ok {
+		return nil, errors.Errorf("unknown protocol %s", protocol)
+	}
+	dstFields := [2]string{"", ""}
+	if hasCidr {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid destination %s", cidr)
+		}
+		if ipNet.IP.To4() != nil {
+			protocols[1] = ""
+			dstFields[0] = fmt.Sprintf("nw_dst=%s,", ipNet)
+		} else {
+			protocols[0] = ""
+			dstFields[1] = fmt.Sprintf("ipv6_dst=%s,", ipNet)
+		}
+	}
+	portFields := []string{""}
+	if hasPorts {
+		if protocol != "tcp" && protocol != "udp" && protocol != "sctp" {
+			return nil, errors.Errorf("protocol %s has no ports", protocol)
+		}
+		masks, err := parsePortRange(portRange)
+		if err != nil {
+			return nil, err
+		}
+		portFields = portFields[:0]
+		for _, mask := range masks {
+			portFields = append(portFields, fmt.Sprintf("tp_dst=%s,", mask))
+		}
+	}
+	var matches []string
+	for family, ovsProtocol := range protocols {
+		if ovsProtocol == "" {
+			continue
+		}
+		for _, portField := range portFields {
+			matches = append(matches, fmt.Sprintf("%s,%s%s", ovsProtocol, portField, dstFields[family]))
+		}
+	}
+	if len(matches) == 0 {
+		return nil, errors.Errorf("protocol %s does not match destination %s", protocol, cidr)
+	}
+	return matches, nil
+}
+
+// parsePortRange returns the port/mask pairs covering the port range, openflow can't match port ranges
+func parsePortRange(portRange string) ([]string, error) {
+	rawMin, rawMax, isRange := strings.Cut(portRange, "-")
+	if !isRange {
+		rawMax = rawMin
+	}
+	minPort, err := strconv.ParseUint(rawMin, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid port %s", rawMin)
+	}
+	maxPort, err := strconv.ParseUint(rawMax, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid port %s", rawMax)
+	}
+	if minPort > maxPort {
+		return nil, errors.Errorf("invalid port range %s", portRange)
+	}
+	if minPort == maxPort {
+		return []string{strconv.FormatUint(minPort, 10)}, nil
+	}
+	var masks []string
+	for port := minPort; port <= maxPort; {
+		size := uint64(1)
+		// grow the block while it stays aligned and within the range
+		for port&(size*2-1) == 0 && port+size*2-1 <= maxPort {
+			size *= 2
+		}
+		masks = append(masks, fmt.Sprintf("0x%x/0x%x", port, 0xffff&^(size-1)))
+		port += size
+	}
+	return masks, nil
+}
+
+// ndICMPv6Types are the neighbor discovery messages, router and neighbor solicitation and advertisement
+var ndICMPv6Types = []int{133, 134, 135, 136}
+
+// flows returns the conntrack and acl stage flows of the direction matching match, nil when acl is nil.
+// The replies of the committed connections and the non ip packets, e.g. ARP, are not subject to the acl.
+// The neighbor discovery bypasses conntrack as ARP does, ipv6 doesn't work without it.
+func (a *connACL) flows(match string, toEndpoint bool) []string {
+	if a == nil {
+		return nil
+	}
+	direction := a.toClient
+	if toEndpoint {
+		direction = a.toEndpoint
+	}
+	flow := func(table, priority int, fields, actions string) string {
+		return fmt.Sprintf("table=%d,priority=%d,%s%sactions=%s", table, priority, match, fields, actions)
+	}
+	track := fmt.Sprintf("ct(zone=%d,table=%d)", a.zone, ovsutil.ACLTable)
+	commit := fmt.Sprintf("ct(commit,zone=%d),%s", a.zone, ovsutil.GotoTableAction(ovsutil.TunnelTable))
+	next := ovsutil.GotoTableAction(ovsutil.TunnelTable)
+
+	flows := []string{
+		flow(ovsutil.ACLTable, 200, "ct_state=-new+est-inv+trk,", next),
+		flow(ovsutil.ACLTable, 200, "ct_state=-new+rel-inv+trk,", next),
+		flow(ovsutil.ACLTable, 190, "ct_state=+inv+trk,", "drop"),
+		flow(ovsutil.ACLTable, 100, "", "drop"),
+	}
+	if !direction.restricted {
+		flows = append(flows, flow(ovsutil.ACLTable, 150, "ct_state=+new+trk,", commit))
+	}
+	for _, aclMatch := range direction.matches {
+		flows = append(flows, flow(ovsutil.ACLTable, 150, "ct_state=+new+trk,"+aclMatch, commit))
+	}
+	for _, icmpType := range ndICMPv6Types {
+		flows = append(flows, flow(ovsutil.ConntrackTable, 110, fmt.Sprintf("icmp6,icmp_type=%d,", icmpType), next))
+	}
+	return append(flows,
+		flow(ovsutil.ConntrackTable, 100, "ip,", track),
+		flow(ovsutil.ConntrackTable, 100, "ipv6,", track))
+}
+
+// addACL returns the acl of the connection with a conntrack zone allocated for it, nil when the connection
+// has no acl label
+func addACL(conn *networkservice.Connection, zones *idPool, ports ...*ifnames.OvsPortInfo) (*connACL, error) {
+	acl, err := getConnACL(conn)
+	if err != nil || acl == nil {
+		return nil, err
+	}
+	acl.zone = zones.allocate()
+	if acl.zone > maxCtZone {
+		zones.free(acl.zone)
+		return nil, errors.New("no conntrack zone left")
+	}
+	for _, port := range ports {
+		port.CtZone = acl.zone
+	}
+	return acl, nil
+}
+
+// deleteACL flushes the conntrack zone of the connection and releases it
+func deleteACL(logger log.Logger, zones *idPool, ports ...*ifnames.OvsPortInfo) {
+	var zone uint32
+	for _, port := range ports {
+		if port.CtZone != 0 {
+			zone = port.CtZone
+		}
+		port.CtZone = 0
+	}
+	if zone == 0 {
+		return
+	}
+	stdout, stderr, err := util.RunOVSAppctl("dpctl/flush-conntrack", fmt.Sprintf("zone=%d", zone))
+	if err != nil {
+		logger.Errorf("Failed to flush conntrack zone %d, stdout: %q, stderr: %q, error: %v", zone, stdout, stderr, err)
+	}
+	zones.free(zone)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortRange(t *testing.T) {
+	for _, sample := range []struct {
+		portRange string
+		expected  []string
+		isErr     bool
+	}{
+		{portRange: "443", expected: []string{"443"}},
+		{portRange: "443-443", expected: []string{"443"}},
+		{portRange: "0-65535", expected: []string{"0x0/0x0"}},
+		{portRange: "1024-2047", expected: []string{"0x400/0xfc00"}},
+		{portRange: "80-81", expected: []string{"0x50/0xfffe"}},
+		{portRange: "5000-5010", expected: []string{"0x1388/0xfff8", "0x1390/0xfffe", "0x1392/0xffff"}},
+		{portRange: "7-8", expected: []string{"0x7/0xffff", "0x8/0xffff"}},
+		{portRange: "", isErr: true},
+		{portRange: "http", isErr: true},
+		{portRange: "65536", isErr: true},
+		{portRange: "10-", isErr: true},
+		{portRange: "10-5", isErr: true},
+	} {
+		t.Run(sample.portRange, func(t *testing.T) {
+			masks, err := parsePortRange(sample.portRange)
+			if sample.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, sample.expected, masks)
+		})
+	}
+}
+
+func TestParseACLEntry(t *testing.T) {
+	for _, sample := range []struct {
+		entry    string
+		expected []string
+		isErr    bool
+	}{
+		{entry: "ip", expected: []string{"ip,", "ipv6,"}},
+		{entry: "icmp", expected: []string{"icmp,"}},
+		{entry: "icmp6", expected: []string{"icmp6,"}},
+		{entry: "tcp:443", expected: []string{"tcp,tp_dst=443,", "tcp6,tp_dst=443,"}},
+		{entry: "udp:80-81", expected: []string{"udp,tp_dst=0x50/0xfffe,", "udp6,tp_dst=0x50/0xfffe,"}},
+		{entry: "ip@10.0.0.0/24", expected: []string{"ip,nw_dst=10.0.0.0/24,"}},
+		{entry: "sctp:5000-5001@fd00::/64", expected: []string{"sctp6,tp_dst=0x1388/0xfffe,ipv6_dst=fd00::/64,"}},
+		{entry: "tcp:22@10.1.2.3/16", expected: []string{"tcp,tp_dst=22,nw_dst=10.1.0.0/16,"}},
+		{entry: "http", isErr: true},
+		{entry: "icmp:8", isErr: true},
+		{entry: "ip:80", isErr: true},
+		{entry: "tcp:0x50", isErr: true},
+		{entry: "tcp@10.0.0.0", isErr: true},
+		{entry: "icmp@fd00::/64", isErr: true},
+		{entry: "icmp6@10.0.0.0/8", isErr: true},
+	} {
+		t.Run(sample.entry, func(t *testing.T) {
+			matches, err := parseACLEntry(sample.entry)
+			if sample.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, sample.expected, matches)
+		})
+	}
+}
@@ -17,7 +17,8 @@
 // limitations under the License.
 
 // Package l2ovsconnect chain element which cross connects both client and endpoint.
-// This suppports both local and remote (vxlan) cross connections.
+// This suppports both local and remote (vxlan) cross connections, and point-to-multipoint l2 domains joining all
+// the connections of a network service endpoint.
 package l2ovsconnect
 
 import (
@@ -38,12 +39,41 @@ import (
 )
 
 type l2ConnectClient struct {
-	bridgeName string
+	bridgeName   string
+	meters       *idPool
+	zones        *idPool
+	portSecurity bool
+	flowSampling uint32
+	obsDomainID  uint32
+	obsPoints    *obsPoints
+	domains      *l2Domains
 }
 
 // NewClient creates l2 connect client
-func NewClient(bridgeName string) networkservice.NetworkServiceClient {
-	return &l2ConnectClient{bridgeName}
+func NewClient(bridgeName string, options ...Option) networkservice.NetworkServiceClient {
+	opts := &l2ConnectOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	c := &l2ConnectClient{
+		bridgeName:   bridgeName,
+		portSecurity: opts.portSecurity,
+		flowSampling: opts.flowSampling,
+		obsDomainID:  opts.obsDomainID,
+	}
+	if opts.meters {
+		c.meters = &idPool{}
+	}
+	if opts.acls {
+		c.zones = &idPool{}
+	}
+	if opts.flowSampling > 0 {
+		c.obsPoints = newObsPoints()
+	}
+	if len(opts.multipointServices) > 0 {
+		c.domains = newL2Domains(opts.multipointServices)
+	}
+	return c
 }
 
 func (c *l2ConnectClient) Request(
@@ -65,7 +95,7 @@ func (c *l2ConnectClient) Request(
 		return conn, err
 	}
 
-	if err := addDel(ctx, logger, conn, c.bridgeName, true); err != nil {
+	if err := c.addDel(ctx, logger, conn, true); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
@@ -80,7 +110,7 @@ func (c *l2ConnectClient) Close(ctx context.Context, conn *networkservice.Connec
 	logger := log.FromContext(ctx).WithField("l2ConnectClient", "Close")
 	_, err := next.Client(ctx).Close(ctx, conn, opts...)
 
-	l2ConnectErr := addDel(ctx, logger, conn, c.bridgeName, false)
+	l2ConnectErr := c.addDel(ctx, logger, conn, false)
 	ifnames.Delete(ctx, metadata.IsClient(c))
 
 	if err != nil && l2ConnectErr != nil {
@@ -93,7 +123,7 @@ func (c *l2ConnectClient) Close(ctx context.Context, conn *networkservice.Connec
 	return &empty.Empty{}, err
 }
 
-func addDel(ctx context.Context, logger log.Logger, conn *networkservice.Connection, bridgeName string, addDel bool) error {
+func (c *l2ConnectClient) addDel(ctx context.Context, logger log.Logger, conn *networkservice.Connection, addDel bool) error {
 	// when mechanism is vlan, then return prematurely, no need of programming cross connect flows.
 	if mechanism := vlanmech.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		return nil
@@ -106,14 +136,69 @@ func addDel(ctx context.Context, logger log.Logger, conn *networkservice.Connect
 	if !ok {
 		return nil
 	}
+	if c.meters != nil {
+		if !addDel {
+			defer deleteMeters(logger, c.bridgeName, c.meters, endpointOvsPortInfo, clientOvsPortInfo)
+		} else if err := addMeters(logger, conn, c.bridgeName, c.meters, endpointOvsPortInfo, clientOvsPortInfo); err != nil {
+			return err
+		}
+	}
+	features := &crossConnectFeatures{}
+	if c.zones != nil {
+		if !addDel {
+			defer deleteACL(logger, c.zones, endpointOvsPortInfo, clientOvsPortInfo)
+		} else {
+			var err error
+			if features.acl, err = addACL(conn, c.zones, endpointOvsPortInfo, clientOvsPortInfo); err != nil {
+				return err
+			}
+		}
+	}
+	if c.portSecurity && addDel {
+		var err error
+		if features.security, err = getPortSecurity(conn); err != nil {
+			return err
+		}
+	}
+	if c.obsPoints != nil {
+		if !addDel {
+			defer c.obsPoints.free(endpointOvsPortInfo, clientOvsPortInfo)
+		} else {
+			obsPointID := c.obsPoints.allocate(conn.GetId(), endpointOvsPortInfo, clientOvsPortInfo)
+			features.sampling = newFlowSampling(logger, conn, c.flowSampling, c.obsDomainID, obsPointID)
+		}
+	}
+	if c.domains != nil && c.domains.isMultipoint(conn) {
+		if features.acl != nil {
+			return errors.New("acls are not supported on multipoint l2 domains")
+		}
+		if addDel {
+			return c.domains.join(logger, c.bridgeName, conn, endpointOvsPortInfo, clientOvsPortInfo, features)
+		}
+		return c.domains.leave(logger, c.bridgeName, endpointOvsPortInfo, clientOvsPortInfo)
+	}
+	return crossConnect(logger, c.bridgeName, endpointOvsPortInfo, clientOvsPortInfo, features, addDel)
+}
+
+func crossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo,
+	features *crossConnectFeatures, addDel bool) error {
+	if !endpointOvsPortInfo.IsTunnelPort && endpointOvsPortInfo.ServiceVlanID > 0 {
+		if features.acl != nil {
+			return errors.New("acls are not supported on QinQ cross connects")
+		}
+		if addDel {
+			return createQinQCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo, features)
+		}
+		return deleteQinQCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo)
+	}
 	if !endpointOvsPortInfo.IsTunnelPort && !clientOvsPortInfo.IsTunnelPort {
 		if addDel {
-			return createLocalCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo)
+			return createLocalCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo, features)
 		}
 		return deleteLocalCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo)
 	}
 	if addDel {
-		return createRemoteCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo)
+		return createRemoteCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo, features)
 	}
 	return deleteRemoteCrossConnect(logger, bridgeName, endpointOvsPortInfo, clientOvsPortInfo)
 }
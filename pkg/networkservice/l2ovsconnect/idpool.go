@@ -0,0 +1,46 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"sync"
+)
+
+// idPool allocates the IDs of a bridge resource, e.g. openflow meters or conntrack zones
+type idPool struct {
+	mutex   sync.Mutex
+	lastID  uint32
+	freeIDs []uint32
+}
+
+func (p *idPool) allocate() uint32 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if n := len(p.freeIDs); n > 0 {
+		id := p.freeIDs[n-1]
+		p.freeIDs = p.freeIDs[:n-1]
+		return id
+	}
+	p.lastID++
+	return p.lastID
+}
+
+func (p *idPool) free(id uint32) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.freeIDs = append(p.freeIDs, id)
+}
@@ -19,8 +19,6 @@
 package l2ovsconnect
 
 import (
-	"fmt"
-
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/pkg/errors"
@@ -29,66 +27,65 @@ import (
 )
 
 func createLocalCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo,
-	clientOvsPortInfo *ifnames.OvsPortInfo) error {
-	var ofRuleToClient, ofRuleToEndpoint string
-	if endpointOvsPortInfo.VlanID > 0 {
-		ofRuleToClient = fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%d,"+
-			"actions=strip_vlan,output:%d", endpointOvsPortInfo.PortNo, endpointOvsPortInfo.VlanID,
-			clientOvsPortInfo.PortNo)
-		ofRuleToEndpoint = fmt.Sprintf("priority=100,in_port=%d,"+
-			"actions=push_vlan:0x8100,set_field:%d->vlan_vid,output:%d", clientOvsPortInfo.PortNo,
-			endpointOvsPortInfo.VlanID+4096, endpointOvsPortInfo.PortNo)
-	} else {
-		ofRuleToClient = fmt.Sprintf("priority=100,in_port=%d,"+
-			"actions=output:%d", endpointOvsPortInfo.PortNo, clientOvsPortInfo.PortNo)
-		ofRuleToEndpoint = fmt.Sprintf("priority=100,in_port=%d,"+
-			"actions=output:%d", clientOvsPortInfo.PortNo, endpointOvsPortInfo.PortNo)
-	}
-	stdout, stderr, err := util.RunOVSOfctl("add-flow", "-OOpenflow13", bridgeName, ofRuleToClient)
-	if err != nil {
-		logger.Infof("Failed to add flow on %s for port %s stdout: %s"+
-			" stderr: %s, error: %v", bridgeName, endpointOvsPortInfo.PortName, stdout, stderr, err)
-		return errors.Wrapf(err, "failed to add flow on %s for port %s stdout: %s stderr: %s", bridgeName, endpointOvsPortInfo.PortName, stdout, stderr)
-	}
-	if stderr != "" {
-		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
-			" stderr: %s", bridgeName, endpointOvsPortInfo.PortName, stdout, stderr)
-	}
-
-	stdout, stderr, err = util.RunOVSOfctl("add-flow", "-OOpenflow13", bridgeName, ofRuleToEndpoint)
-	if err != nil {
-		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
-			" stderr: %s, error: %v", bridgeName, clientOvsPortInfo.PortName, stdout, stderr, err)
-		return errors.Wrapf(err, "Failed to add flow on %s for port %s stdout: %s stderr: %s", bridgeName, clientOvsPortInfo.PortName, stdout, stderr)
+	clientOvsPortInfo *ifnames.OvsPortInfo, features *crossConnectFeatures) error {
+	toClient, toEndpoint := getLocalCrossConnectDirections(endpointOvsPortInfo, clientOvsPortInfo, features)
+	if err := toClient.addFlows(logger, bridgeName); err != nil {
+		return err
 	}
-
-	if stderr != "" {
-		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
-			" stderr: %s", bridgeName, clientOvsPortInfo.PortName, stdout, stderr)
+	if err := toEndpoint.addFlows(logger, bridgeName); err != nil {
+		return err
 	}
 
 	endpointOvsPortInfo.IsCrossConnected = true
 	clientOvsPortInfo.IsCrossConnected = true
+	if security := features.security; security != nil {
+		endpointOvsPortInfo.IsPortSecured = security.endpoint.isSet()
+		clientOvsPortInfo.IsPortSecured = security.client.isSet()
+	}
 
 	return nil
 }
 
+func getLocalCrossConnectDirections(endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo,
+	features *crossConnectFeatures) (toClient, toEndpoint *crossConnectDirection) {
+	toClient = &crossConnectDirection{
+		match:        portMatch(endpointOvsPortInfo) + ",",
+		portName:     endpointOvsPortInfo.PortName,
+		meterID:      endpointOvsPortInfo.MeterID,
+		encapActions: vlanActions(endpointOvsPortInfo.VlanID, clientOvsPortInfo.VlanID),
+		outPortNo:    clientOvsPortInfo.PortNo,
+		acl:          features.acl,
+		sampling:     features.sampling,
+	}
+	toEndpoint = &crossConnectDirection{
+		match:        portMatch(clientOvsPortInfo) + ",",
+		portName:     clientOvsPortInfo.PortName,
+		meterID:      clientOvsPortInfo.MeterID,
+		encapActions: vlanActions(clientOvsPortInfo.VlanID, endpointOvsPortInfo.VlanID),
+		outPortNo:    endpointOvsPortInfo.PortNo,
+		acl:          features.acl,
+		sampling:     features.sampling,
+		toEndpoint:   true,
+	}
+	toClient.hairpin = endpointOvsPortInfo.PortNo == clientOvsPortInfo.PortNo
+	toEndpoint.hairpin = toClient.hairpin
+	if security := features.security; security != nil {
+		toClient.security = security.endpoint
+		toEndpoint.security = security.client
+	}
+	return toClient, toEndpoint
+}
+
 func deleteLocalCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo,
 	clientOvsPortInfo *ifnames.OvsPortInfo) error {
-	var matchForEndpoint string
-	if endpointOvsPortInfo.VlanID > 0 {
-		matchForEndpoint = fmt.Sprintf("in_port=%d,dl_vlan=%d", endpointOvsPortInfo.PortNo, endpointOvsPortInfo.VlanID)
-	} else {
-		matchForEndpoint = fmt.Sprintf("in_port=%d", endpointOvsPortInfo.PortNo)
-	}
-	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, matchForEndpoint)
+	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, portMatch(endpointOvsPortInfo))
 	if err != nil {
 		logger.Errorf("Failed to delete flow on %s for port "+
 			"%s, stdout: %q, stderr: %q, error: %v", bridgeName, endpointOvsPortInfo.PortName, stdout, stderr, err)
 		return errors.Wrapf(err, "failed to delete flow on %s for port %s, stdout: %q, stderr: %q", bridgeName, endpointOvsPortInfo.PortName, stdout, stderr)
 	}
 
-	stdout, stderr, err = util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, fmt.Sprintf("in_port=%d", clientOvsPortInfo.PortNo))
+	stdout, stderr, err = util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, portMatch(clientOvsPortInfo))
 	if err != nil {
 		logger.Errorf("Failed to delete flow on %s for port "+
 			"%s, stdout: %q, stderr: %q, error: %v", bridgeName, clientOvsPortInfo.PortName, stdout, stderr, err)
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+const (
+	// MeterRateLabel - rate in kbps of the meter attached to each direction of the cross connect
+	MeterRateLabel = "meter.rate"
+	// MeterBurstLabel - burst size in kb of the meter attached to each direction of the cross connect
+	MeterBurstLabel = "meter.burst"
+)
+
+func getMeterConfig(conn *networkservice.Connection) (rate, burst uint64, err error) {
+	rawRate, ok := conn.GetLabels()[MeterRateLabel]
+	if !ok {
+		return 0, 0, nil
+	}
+	if rate, err = strconv.ParseUint(rawRate, 10, 32); err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid value %q of label %s", rawRate, MeterRateLabel)
+	}
+	if rawBurst, ok := conn.GetLabels()[MeterBurstLabel]; ok {
+		if burst, err = strconv.ParseUint(rawBurst, 10, 32); err != nil {
+			return 0, 0, errors.Wrapf(err, "invalid value %q of label %s", rawBurst, MeterBurstLabel)
+		}
+	}
+	return rate, burst, nil
+}
+
+// addMeters creates a meter for the traffic received on each of the given ports
+func addMeters(logger log.Logger, conn *networkservice.Connection, bridgeName string, meters *idPool, ports ...*ifnames.OvsPortInfo) error {
+	rate, burst, err := getMeterConfig(conn)
+	if err != nil || rate == 0 {
+		return err
+	}
+	for _, port := range ports {
+		meterID := meters.allocate()
+		meter := fmt.Sprintf("meter=%d,kbps,band=type=drop,rate=%d", meterID, rate)
+		if burst > 0 {
+			meter = fmt.Sprintf("meter=%d,kbps,burst,band=type=drop,rate=%d,burst_size=%d", meterID, rate, burst)
+		}
+		stdout, stderr, addErr := util.RunOVSOfctl("add-meter", "-OOpenflow13", bridgeName, meter)
+		if addErr != nil {
+			meters.free(meterID)
+			logger.Errorf("Failed to add meter on %s for port %s stdout: %s"+
+				" stderr: %s, error: %v", bridgeName, port.PortName, stdout, stderr, addErr)
+			return errors.Wrapf(addErr, "failed to add meter on %s for port %s stdout: %s stderr: %s", bridgeName, port.PortName, stdout, stderr)
+		}
+		port.MeterID = meterID
+	}
+	return nil
+}
+
+// deleteMeters deletes the meters of the given ports and releases their IDs
+func deleteMeters(logger log.Logger, bridgeName string, meters *idPool, ports ...*ifnames.OvsPortInfo) {
+	for _, port := range ports {
+		if port.MeterID == 0 {
+			continue
+		}
+		stdout, stderr, err := util.RunOVSOfctl("del-meter", "-OOpenflow13", bridgeName, fmt.Sprintf("meter=%d", port.MeterID))
+		if err != nil {
+			logger.Errorf("Failed to delete meter %d on %s for port %s, stdout: %q, stderr: %q, error: %v",
+				port.MeterID, bridgeName, port.PortName, stdout, stderr, err)
+			continue
+		}
+		meters.free(port.MeterID)
+		port.MeterID = 0
+	}
+}
+
+// withMeter attaches the meter to the openflow rule before its actions are applied
+func withMeter(ofRule string, meterID uint32) string {
+	if meterID == 0 {
+		return ofRule
+	}
+	return strings.Replace(ofRule, "actions=", fmt.Sprintf("actions=meter:%d,", meterID), 1)
+}
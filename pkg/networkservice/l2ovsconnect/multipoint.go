@@ -0,0 +1,344 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// registers of the multipoint l2 domains, REG1 carries the output port, see ovsutil.SetOutputPortAction
+const (
+	domainRegister        = "NXM_NX_REG2[]"
+	ingressMemberRegister = "NXM_NX_REG3[]"
+	egressMemberRegister  = "NXM_NX_REG4[]"
+	ingressKeyRegister    = "NXM_NX_REG5[]"
+)
+
+const (
+	// macIdleTimeout - idle timeout in seconds of the learned MAC addresses
+	macIdleTimeout = 300
+	// endpointMemberKey - key of the local endpoint ports of a domain, they all lead to the same endpoint.
+	// It is above the range of the openflow port numbers which are the keys of the tunnel members.
+	endpointMemberKey = 0xfffffffe
+)
+
+// l2Domains are the multipoint l2 domains of the bridge. All the connections of a multipoint network service to
+// the same endpoint join one domain, in which each connection is a member with both its nsc and endpoint ports.
+type l2Domains struct {
+	services  map[string]bool
+	mutex     sync.Mutex
+	domainIDs idPool
+	memberIDs idPool
+	domains   map[string]*l2Domain
+	members   map[uint32]*l2Domain
+}
+
+type l2Domain struct {
+	name    string
+	id      uint32
+	members map[uint32]*domainMember
+}
+
+// domainMember is a port of a domain, the tunnel and VLAN trunk ports are members for a VNI or a VLAN only
+type domainMember struct {
+	id       uint32
+	portName string
+	portNo   int
+	// match of the packets received from the member, e.g. "in_port=1,tun_id=100,"
+	match string
+	// ingressActions are applied to the packets received from the member, egressActions to the packets sent to it
+	ingressActions string
+	egressActions  string
+	// key of the members leading to the same place, i.e. the members on the same tunnel port or the local endpoint
+	// ports. The packets are flooded to one member of each key and never to the members of their ingress key.
+	// The key of the members leading to a single nsc is 0.
+	key      uint32
+	hasGroup bool
+}
+
+func newL2Domains(services []string) *l2Domains {
+	d := &l2Domains{
+		services: make(map[string]bool),
+		domains:  make(map[string]*l2Domain),
+		members:  make(map[uint32]*l2Domain),
+	}
+	for _, service := range services {
+		d.services[service] = true
+	}
+	return d
+}
+
+func (d *l2Domains) isMultipoint(conn *networkservice.Connection) bool {
+	return d.services[conn.GetNetworkService()]
+}
+
+// join adds the nsc and the endpoint ports of the connection to the domain of its network service endpoint
+func (d *l2Domains) join(logger log.Logger, bridgeName string, conn *networkservice.Connection, endpointOvsPortInfo,
+	clientOvsPortInfo *ifnames.OvsPortInfo, features *crossConnectFeatures) error {
+	if endpointOvsPortInfo.ServiceVlanID > 0 {
+		return errors.New("QinQ connections can't join a multipoint l2 domain")
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	name := conn.GetNetworkService() + "/" + conn.GetNetworkServiceEndpointName()
+	domain, ok := d.domains[name]
+	if !ok {
+		domain = &l2Domain{name: name, id: d.domainIDs.allocate(), members: make(map[uint32]*domainMember)}
+		d.domains[name] = domain
+	}
+	clientMember := newDomainMember(d.memberIDs.allocate(), clientOvsPortInfo, false)
+	endpointMember := newDomainMember(d.memberIDs.allocate(), endpointOvsPortInfo, true)
+	for _, member := range []*domainMember{clientMember, endpointMember} {
+		domain.members[member.id] = member
+		d.members[member.id] = domain
+	}
+	clientOvsPortInfo.DomainMemberID = clientMember.id
+	endpointOvsPortInfo.DomainMemberID = endpointMember.id
+	logger.Infof("connection %s joins l2 domain %s with members %d and %d", conn.GetId(), name, clientMember.id, endpointMember.id)
+
+	// the groups and the forwarding flows of the new members are programmed before their ingress flows
+	if err := domain.updateGroups(logger, bridgeName); err != nil {
+		return err
+	}
+	toEndpoint := domain.ingress(clientMember, clientOvsPortInfo, features.sampling)
+	toClient := domain.ingress(endpointMember, endpointOvsPortInfo, features.sampling)
+	if security := features.security; security != nil {
+		if !clientOvsPortInfo.IsTunnelPort {
+			toEndpoint.security = security.client
+			clientOvsPortInfo.IsPortSecured = security.client.isSet()
+		}
+		if !endpointOvsPortInfo.IsTunnelPort {
+			toClient.security = security.endpoint
+			endpointOvsPortInfo.IsPortSecured = security.endpoint.isSet()
+		}
+	}
+	for _, member := range []*domainMember{clientMember, endpointMember} {
+		if err := member.addForwardingFlows(logger, bridgeName); err != nil {
+			return err
+		}
+	}
+	if err := toEndpoint.addFlows(logger, bridgeName); err != nil {
+		return err
+	}
+	if err := toClient.addFlows(logger, bridgeName); err != nil {
+		return err
+	}
+
+	endpointOvsPortInfo.IsCrossConnected = true
+	clientOvsPortInfo.IsCrossConnected = true
+	return nil
+}
+
+// leave removes the ports of the connection from their domain, the domain is deleted with its last member
+func (d *l2Domains) leave(logger log.Logger, bridgeName string, endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var firstErr error
+	var domain *l2Domain
+	for _, port := range []*ifnames.OvsPortInfo{clientOvsPortInfo, endpointOvsPortInfo} {
+		memberDomain, ok := d.members[port.DomainMemberID]
+		if !ok {
+			continue
+		}
+		domain = memberDomain
+		member := domain.members[port.DomainMemberID]
+		// the flows learned on the member are deleted with its ingress flows
+		if err := member.delete(logger, bridgeName); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(domain.members, member.id)
+		delete(d.members, member.id)
+		d.memberIDs.free(member.id)
+		port.DomainMemberID = 0
+	}
+	if domain == nil {
+		return firstErr
+	}
+	if len(domain.members) == 0 {
+		delete(d.domains, domain.name)
+		d.domainIDs.free(domain.id)
+		return firstErr
+	}
+	if err := domain.updateGroups(logger, bridgeName); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func newDomainMember(id uint32, port *ifnames.OvsPortInfo, isEndpoint bool) *domainMember {
+	member := &domainMember{
+		id:       id,
+		portName: port.PortName,
+		portNo:   port.PortNo,
+		match:    fmt.Sprintf("in_port=%d,", port.PortNo),
+	}
+	switch {
+	case port.IsTunnelPort:
+		member.match = fmt.Sprintf("in_port=%d,tun_id=%d,", port.PortNo, port.VNI)
+		member.egressActions = fmt.Sprintf("set_field:%d->tun_id", port.VNI)
+		member.key = uint32(port.PortNo)
+	case port.VlanID > 0:
+		member.match = fmt.Sprintf("in_port=%d,dl_vlan=%d,", port.PortNo, port.VlanID)
+		member.ingressActions = "strip_vlan"
+		member.egressActions = fmt.Sprintf("push_vlan:0x8100,set_field:%d->vlan_vid", port.VlanID+4096)
+	}
+	if isEndpoint && !port.IsTunnelPort {
+		member.key = endpointMemberKey
+	}
+	return member
+}
+
+// ingress returns the direction of the packets received from the member: they are decapsulated in the tunnel
+// stage, which learns their source MAC on the member and looks their destination MAC up in the domain
+func (d *l2Domain) ingress(member *domainMember, port *ifnames.OvsPortInfo, sampling *flowSampling) *crossConnectDirection {
+	forwardActions := []string{
+		fmt.Sprintf("load:%d->%s", d.id, domainRegister),
+		fmt.Sprintf("load:%d->%s", member.id, ingressMemberRegister),
+	}
+	if member.key != 0 {
+		forwardActions = append(forwardActions, fmt.Sprintf("load:%d->%s", member.key, ingressKeyRegister))
+	}
+	forwardActions = append(forwardActions,
+		fmt.Sprintf("learn(table=%d,idle_timeout=%d,priority=100,delete_learned,%s,NXM_OF_ETH_DST[]=NXM_OF_ETH_SRC[],load:%s->%s)",
+			ovsutil.L2LearningTable, macIdleTimeout, domainRegister, ingressMemberRegister, egressMemberRegister),
+		fmt.Sprintf("resubmit(,%d)", ovsutil.L2LearningTable),
+		ovsutil.GotoTableAction(ovsutil.L2ForwardingTable),
+	)
+	return &crossConnectDirection{
+		match:          member.match,
+		portName:       member.portName,
+		meterID:        port.MeterID,
+		encapActions:   member.ingressActions,
+		forwardActions: strings.Join(forwardActions, ","),
+		sampling:       sampling,
+	}
+}
+
+// floodMembers returns the members the packets received from the ingress member are flooded to, the member
+// with the lowest ID of each key but the ingress one, and all the members of key 0
+func (d *l2Domain) floodMembers(ingress *domainMember) []*domainMember {
+	ids := make([]uint32, 0, len(d.members))
+	for id := range d.members {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	keys := make(map[uint32]bool)
+	if ingress.key != 0 {
+		keys[ingress.key] = true
+	}
+	var members []*domainMember
+	for _, id := range ids {
+		member := d.members[id]
+		if member == ingress {
+			continue
+		}
+		if member.key != 0 {
+			if keys[member.key] {
+				continue
+			}
+			keys[member.key] = true
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+// updateGroups programs the flood group of each member, the group ID is the member ID
+func (d *l2Domain) updateGroups(logger log.Logger, bridgeName string) error {
+	for _, member := range d.members {
+		group := fmt.Sprintf("group_id=%d,type=all", member.id)
+		for _, floodMember := range d.floodMembers(member) {
+			group += ",bucket=actions=" + floodMember.withEgressActions(fmt.Sprintf("output:%d", floodMember.portNo))
+		}
+		command := "add-group"
+		if member.hasGroup {
+			command = "mod-group"
+		}
+		stdout, stderr, err := util.RunOVSOfctl(command, "-OOpenflow13", bridgeName, group)
+		if err != nil {
+			logger.Errorf("Failed to program flood group of l2 domain %s on %s for port %s, stdout: %q, stderr: %q, error: %v",
+				d.name, bridgeName, member.portName, stdout, stderr, err)
+			return errors.Wrapf(err, "failed to program flood group of l2 domain %s on %s for port %s, stdout: %q, stderr: %q",
+				d.name, bridgeName, member.portName, stdout, stderr)
+		}
+		member.hasGroup = true
+	}
+	return nil
+}
+
+// addForwardingFlows programs the forwarding of the packets to the member when its MAC addresses are learned,
+// and the flooding of the packets received from it otherwise
+func (m *domainMember) addForwardingFlows(logger log.Logger, bridgeName string) error {
+	var flows []string
+	if m.key != 0 {
+		flows = append(flows, fmt.Sprintf("table=%d,priority=110,reg4=%d,reg5=%d,actions=drop", ovsutil.L2ForwardingTable, m.id, m.key))
+	}
+	flows = append(flows,
+		fmt.Sprintf("table=%d,priority=100,reg4=%d,actions=%s", ovsutil.L2ForwardingTable, m.id,
+			m.withEgressActions(ovsutil.SetOutputPortAction(m.portNo), ovsutil.GotoTableAction(ovsutil.OutputTable))),
+		fmt.Sprintf("table=%d,priority=10,reg3=%d,actions=group:%d", ovsutil.L2ForwardingTable, m.id, m.id),
+	)
+	for _, ofRule := range flows {
+		if err := addFlow(logger, bridgeName, m.portName, ofRule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete deletes the ingress and forwarding flows and the flood group of the member
+func (m *domainMember) delete(logger log.Logger, bridgeName string) error {
+	var firstErr error
+	for _, args := range [][]string{
+		{"del-flows", "-OOpenflow13", bridgeName, strings.TrimSuffix(m.match, ",")},
+		{"del-flows", "-OOpenflow13", bridgeName, fmt.Sprintf("table=%d,reg3=%d", ovsutil.L2ForwardingTable, m.id)},
+		{"del-flows", "-OOpenflow13", bridgeName, fmt.Sprintf("table=%d,reg4=%d", ovsutil.L2ForwardingTable, m.id)},
+		{"del-groups", "-OOpenflow13", bridgeName, fmt.Sprintf("group_id=%d", m.id)},
+	} {
+		stdout, stderr, err := util.RunOVSOfctl(args...)
+		if err != nil {
+			logger.Errorf("Failed to %s on %s for port %s, stdout: %q, stderr: %q, error: %v",
+				args[0], bridgeName, m.portName, stdout, stderr, err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "failed to %s on %s for port %s, stdout: %q, stderr: %q",
+					args[0], bridgeName, m.portName, stdout, stderr)
+			}
+		}
+	}
+	return firstErr
+}
+
+func (m *domainMember) withEgressActions(actions ...string) string {
+	if m.egressActions != "" {
+		actions = append([]string{m.egressActions}, actions...)
+	}
+	return strings.Join(actions, ",")
+}
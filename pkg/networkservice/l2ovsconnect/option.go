@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+// Option is an option pattern for l2 connect client
+type Option func(o *l2ConnectOptions)
+
+// WithMeters enables openflow meters on both directions of the cross connects which carry
+// meter rate in their labels
+func WithMeters() Option {
+	return func(o *l2ConnectOptions) {
+		o.meters = true
+	}
+}
+
+// WithPortSecurity enables port security on the local ports of the cross connects: each port may send only from the
+// MAC and IP addresses of its side of the connection context, ARP and ND are permitted only for these IPs. Everything
+// else is dropped and counted. The tunnel ports and the service VLAN trunks of the QinQ endpoints are not secured,
+// they carry the traffic of many connections.
+func WithPortSecurity() Option {
+	return func(o *l2ConnectOptions) {
+		o.portSecurity = true
+	}
+}
+
+// WithACLs enables stateful acls on the cross connects which carry acl labels, see ACLToEndpointLabel and
+// ACLToClientLabel. The traffic of such cross connects goes through conntrack in a zone of its own, the replies
+// of the allowed connections are let back.
+func WithACLs() Option {
+	return func(o *l2ConnectOptions) {
+		o.acls = true
+	}
+}
+
+// WithFlowSampling samples 1 out of sampling packets of each direction of the cross connects to the IPFIX flow
+// sample collector set of the bridge, see ovsutil.FlowSampleCollectorSetID. The samples carry the observation
+// point ID of the connection, which is also the cookie of the cross connect flows. The ID is published in the path
+// segment metrics of the connection by the stats chain element.
+func WithFlowSampling(sampling, obsDomainID uint32) Option {
+	return func(o *l2ConnectOptions) {
+		o.flowSampling = sampling
+		o.obsDomainID = obsDomainID
+	}
+}
+
+// WithMultipoint joins the connections of the given network services in point-to-multipoint l2 domains instead
+// of cross connecting them, one domain per network service endpoint. The unicast packets are forwarded by the
+// learned MAC addresses, the others are flooded to the local ports and the tunnels of the domain.
+func WithMultipoint(services ...string) Option {
+	return func(o *l2ConnectOptions) {
+		o.multipointServices = append(o.multipointServices, services...)
+	}
+}
+
+type l2ConnectOptions struct {
+	meters       bool
+	portSecurity bool
+	acls         bool
+	flowSampling uint32
+	obsDomainID  uint32
+	// multipointServices are the network services of the multipoint l2 domains
+	multipointServices []string
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// crossConnectFeatures are the optional features of a cross connect
+type crossConnectFeatures struct {
+	security *portSecurity
+	acl      *connACL
+	sampling *flowSampling
+}
+
+// crossConnectDirection is a direction of a cross connect, it is programmed in each stage of the pipeline
+// with the flows matching the packets received on its ingress port
+type crossConnectDirection struct {
+	// match of the packets received on the ingress port, e.g. "in_port=1,dl_vlan=100,"
+	match    string
+	portName string
+	meterID  uint32
+	// encapActions are applied in the tunnel stage before the packets are output to outPortNo
+	encapActions string
+	outPortNo    int
+	// hairpin is set when the packets leave on their ingress port, e.g. between two vhost-user ports behind the
+	// link to the netdev bridge, openflow drops the packets output to their ingress port otherwise
+	hairpin bool
+	// forwardActions replace the output port selection of the tunnel stage, e.g. the mac learning and forwarding
+	// of a multipoint l2 domain
+	forwardActions string
+	security       *portAddresses
+	acl            *connACL
+	sampling       *flowSampling
+	toEndpoint     bool
+}
+
+// flows returns the flows of each stage of the pipeline. The stages are programmed from the output backwards,
+// so that packets are not classified into a partially programmed pipeline.
+func (d *crossConnectDirection) flows() []string {
+	tunnelActions := []string{ovsutil.SetOutputPortAction(d.outPortNo), ovsutil.GotoTableAction(ovsutil.OutputTable)}
+	if d.hairpin {
+		tunnelActions = []string{"in_port"}
+	}
+	if d.forwardActions != "" {
+		tunnelActions = []string{d.forwardActions}
+	}
+	if d.encapActions != "" {
+		tunnelActions = append([]string{d.encapActions}, tunnelActions...)
+	}
+	flows := []string{
+		fmt.Sprintf("table=%d,priority=100,%sactions=%s", ovsutil.TunnelTable, d.match, strings.Join(tunnelActions, ",")),
+	}
+	flows = append(flows, d.acl.flows(d.match, d.toEndpoint)...)
+	flows = append(flows, d.security.flows(d.match)...)
+	classificationActions := ovsutil.GotoTableAction(ovsutil.SecurityTable)
+	if d.sampling != nil {
+		classificationActions = d.sampling.action + "," + classificationActions
+	}
+	classification := fmt.Sprintf("table=%d,priority=100,%sactions=%s", ovsutil.ClassificationTable, d.match, classificationActions)
+	return d.sampling.withCookie(append(flows, withMeter(classification, d.meterID)))
+}
+
+// addFlows programs the direction in the pipeline
+func (d *crossConnectDirection) addFlows(logger log.Logger, bridgeName string) error {
+	for _, ofRule := range d.flows() {
+		if err := addFlow(logger, bridgeName, d.portName, ofRule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
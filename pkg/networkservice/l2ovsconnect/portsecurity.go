@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// priorities of the security stage flows
+const (
+	ndAllowPriority = 120
+	ndDropPriority  = 115
+	allowPriority   = 110
+	dropPriority    = 90
+)
+
+// portSecurity pins the source addresses of both the nsc (client) and the endpoint port of a local cross connect
+type portSecurity struct {
+	client, endpoint *portAddresses
+}
+
+// portAddresses are the source addresses a port of a cross connect is allowed to send from
+type portAddresses struct {
+	mac string
+	ips []net.IP
+}
+
+// getPortSecurity returns the addresses of the nsc (source) and the endpoint (destination) side of the connection
+func getPortSecurity(conn *networkservice.Connection) (*portSecurity, error) {
+	ethernetContext := conn.GetContext().GetEthernetContext()
+	ipContext := conn.GetContext().GetIpContext()
+	client, err := newPortAddresses(ethernetContext.GetSrcMac(), ipContext.GetSrcIpAddrs())
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := newPortAddresses(ethernetContext.GetDstMac(), ipContext.GetDstIpAddrs())
+	if err != nil {
+		return nil, err
+	}
+	return &portSecurity{client: client, endpoint: endpoint}, nil
+}
+
+func newPortAddresses(mac string, ipAddrs []string) (*portAddresses, error) {
+	addresses := &portAddresses{}
+	if mac != "" {
+		hwAddr, err := net.ParseMAC(mac)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid mac address %s", mac)
+		}
+		addresses.mac = hwAddr.String()
+	}
+	for _, ipAddr := range ipAddrs {
+		ip, _, err := net.ParseCIDR(ipAddr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ip address %s", ipAddr)
+		}
+		addresses.ips = append(addresses.ips, ip)
+	}
+	return addresses, nil
+}
+
+// flows returns the security stage flows letting only the packets sent from the port addresses through to the
+// next stage, ARP and ND are permitted only for the port IPs. Everything else is dropped by a dedicated flow
+// counting the drops. No flow is returned when the port has no address to pin.
+func (a *portAddresses) flows(match string) []string {
+	if !a.isSet() {
+		return nil
+	}
+	dlSrc := ""
+	if a.mac != "" {
+		dlSrc = fmt.Sprintf("dl_src=%s,", a.mac)
+	}
+	next := ovsutil.GotoTableAction(ovsutil.ConntrackTable)
+	flow := func(priority int, fields, actions string) string {
+		return fmt.Sprintf("table=%d,priority=%d,%s%sactions=%s", ovsutil.SecurityTable, priority, match, fields, actions)
+	}
+
+	if len(a.ips) == 0 {
+		return []string{flow(allowPriority, dlSrc, next), flow(dropPriority, "", "drop")}
+	}
+	var flows []string
+	var hasIPv6 bool
+	for _, ip := range a.ips {
+		if ip.To4() != nil {
+			arpSha := ""
+			if a.mac != "" {
+				arpSha = fmt.Sprintf("arp_sha=%s,", a.mac)
+			}
+			flows = append(flows,
+				flow(allowPriority, fmt.Sprintf("ip,%snw_src=%s,", dlSrc, ip), next),
+				flow(allowPriority, fmt.Sprintf("arp,%s%sarp_spa=%s,", dlSrc, arpSha, ip), next))
+			continue
+		}
+		hasIPv6 = true
+		flows = append(flows,
+			flow(allowPriority, fmt.Sprintf("ipv6,%sipv6_src=%s,", dlSrc, ip), next),
+			flow(ndAllowPriority, fmt.Sprintf("icmp6,icmp_type=135,%sipv6_src=%s,", dlSrc, ip), next),
+			// duplicate address detection
+			flow(ndAllowPriority, fmt.Sprintf("icmp6,icmp_type=135,%sipv6_src=::,nd_target=%s,", dlSrc, ip), next),
+			flow(ndAllowPriority, fmt.Sprintf("icmp6,icmp_type=136,%snd_target=%s,", dlSrc, ip), next))
+	}
+	if hasIPv6 {
+		// keep the neighbor discovery of foreign addresses off the ipv6 allow flows
+		flows = append(flows,
+			flow(ndDropPriority, "icmp6,icmp_type=135,", "drop"),
+			flow(ndDropPriority, "icmp6,icmp_type=136,", "drop"))
+	}
+	return append(flows, flow(dropPriority, "", "drop"))
+}
+
+func (a *portAddresses) isSet() bool {
+	return a != nil && (a.mac != "" || len(a.ips) > 0)
+}
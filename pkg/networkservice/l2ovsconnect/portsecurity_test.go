@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+func TestRemoteCrossConnectPortSecurity(t *testing.T) {
+	security := &portSecurity{
+		client:   &portAddresses{mac: "02:00:00:00:00:01", ips: []net.IP{net.ParseIP("10.0.0.1")}},
+		endpoint: &portAddresses{mac: "02:00:00:00:00:02", ips: []net.IP{net.ParseIP("10.0.0.2")}},
+	}
+	localPort := &ifnames.OvsPortInfo{PortName: "local", PortNo: 1}
+	tunnelPort := &ifnames.OvsPortInfo{PortName: "vxlan", PortNo: 2, IsTunnelPort: true, VNI: 100}
+
+	for _, sample := range []struct {
+		name             string
+		endpoint, client *ifnames.OvsPortInfo
+		allowed          string
+	}{
+		{name: "local client", endpoint: tunnelPort, client: localPort, allowed: "02:00:00:00:00:01,nw_src=10.0.0.1"},
+		{name: "local endpoint", endpoint: localPort, client: tunnelPort, allowed: "02:00:00:00:00:02,nw_src=10.0.0.2"},
+	} {
+		t.Run(sample.name, func(t *testing.T) {
+			fromLocal, fromTunnel := getRemoteCrossConnectDirections(sample.endpoint, sample.client,
+				&crossConnectFeatures{security: security})
+			require.Contains(t, fromLocal.flows(),
+				"table=10,priority=110,in_port=1,ip,dl_src="+sample.allowed+",actions=goto_table:20")
+			require.Contains(t, fromLocal.flows(), "table=10,priority=90,in_port=1,actions=drop")
+			for _, flow := range fromTunnel.flows() {
+				require.False(t, strings.HasPrefix(flow, "table=10,"), flow)
+			}
+		})
+	}
+}
+
+func TestQinQCrossConnectPortSecurity(t *testing.T) {
+	features := &crossConnectFeatures{security: &portSecurity{client: &portAddresses{mac: "02:00:00:00:00:01"}}}
+	endpoint := &ifnames.OvsPortInfo{PortName: "trunk", PortNo: 3, ServiceVlanID: 100, VlanID: 10}
+	client := &ifnames.OvsPortInfo{PortName: "nsc", PortNo: 1}
+
+	toEndpoint := getQinQToEndpointDirection(endpoint, client, features)
+	require.Contains(t, toEndpoint.flows(), "table=10,priority=110,in_port=1,dl_src=02:00:00:00:00:01,actions=goto_table:20")
+
+	client = &ifnames.OvsPortInfo{PortName: "vxlan", PortNo: 2, IsTunnelPort: true, VNI: 100}
+	require.Nil(t, getQinQToEndpointDirection(endpoint, client, features).security)
+}
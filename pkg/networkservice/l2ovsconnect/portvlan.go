@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+// portMatch returns the match of the packets received on the port, without the trailing comma. The connections
+// sharing an ovs port, e.g. the vhost-user ports behind the link to the netdev bridge, are told apart by the
+// VLAN ID of their port.
+func portMatch(port *ifnames.OvsPortInfo) string {
+	if port.VlanID > 0 {
+		return fmt.Sprintf("in_port=%d,dl_vlan=%d", port.PortNo, port.VlanID)
+	}
+	return fmt.Sprintf("in_port=%d", port.PortNo)
+}
+
+// vlanActions returns the actions removing the VLAN tag of the ingress port and adding the one of the egress
+// port, followed by the given actions
+func vlanActions(ingressVlanID, egressVlanID uint32, actions ...string) string {
+	var vlanActions []string
+	if ingressVlanID > 0 {
+		vlanActions = append(vlanActions, "strip_vlan")
+	}
+	if egressVlanID > 0 {
+		vlanActions = append(vlanActions, fmt.Sprintf("push_vlan:0x8100,set_field:%d->vlan_vid", egressVlanID+4096))
+	}
+	return strings.Join(append(vlanActions, actions...), ",")
+}
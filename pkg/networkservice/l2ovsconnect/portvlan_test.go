@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+const vhostUserLinkPortNo = 5
+
+func vhostUserPort(vlanID uint32) *ifnames.OvsPortInfo {
+	return &ifnames.OvsPortInfo{PortName: "nsm-vhu-link", PortNo: vhostUserLinkPortNo, VlanID: vlanID}
+}
+
+// flowMatches returns the table, priority and match of the flows, the flows having the same ones replace each other
+func flowMatches(directions ...*crossConnectDirection) map[string]string {
+	matches := make(map[string]string)
+	for _, direction := range directions {
+		for _, flow := range direction.flows() {
+			match, actions, _ := strings.Cut(flow, "actions=")
+			matches[match] = actions
+		}
+	}
+	return matches
+}
+
+func TestVhostUserCrossConnects(t *testing.T) {
+	for _, sample := range []struct {
+		name        string
+		directions  func(endpoint, client *ifnames.OvsPortInfo) (*crossConnectDirection, *crossConnectDirection)
+		endpoints   [2]*ifnames.OvsPortInfo
+		toClient    [2]string
+		toEndpoint  [2]string
+		deleteMatch [2]string
+	}{
+		{
+			name: "local",
+			directions: func(endpoint, client *ifnames.OvsPortInfo) (*crossConnectDirection, *crossConnectDirection) {
+				return getLocalCrossConnectDirections(endpoint, client, &crossConnectFeatures{})
+			},
+			endpoints: [2]*ifnames.OvsPortInfo{{PortName: "nse-1", PortNo: 10}, {PortName: "nse-2", PortNo: 11}},
+			toClient: [2]string{
+				"table=30,priority=100,in_port=10,actions=push_vlan:0x8100,set_field:4097->vlan_vid,load:5->NXM_NX_REG1[],goto_table:40",
+				"table=30,priority=100,in_port=11,actions=push_vlan:0x8100,set_field:4098->vlan_vid,load:5->NXM_NX_REG1[],goto_table:40",
+			},
+			toEndpoint: [2]string{
+				"table=30,priority=100,in_port=5,dl_vlan=1,actions=strip_vlan,load:10->NXM_NX_REG1[],goto_table:40",
+				"table=30,priority=100,in_port=5,dl_vlan=2,actions=strip_vlan,load:11->NXM_NX_REG1[],goto_table:40",
+			},
+			deleteMatch: [2]string{"in_port=5,dl_vlan=1", "in_port=5,dl_vlan=2"},
+		},
+		{
+			name: "remote",
+			directions: func(endpoint, client *ifnames.OvsPortInfo) (*crossConnectDirection, *crossConnectDirection) {
+				fromLocal, fromTunnel := getRemoteCrossConnectDirections(endpoint, client, &crossConnectFeatures{})
+				return fromTunnel, fromLocal
+			},
+			endpoints: [2]*ifnames.OvsPortInfo{
+				{PortName: "vxlan", PortNo: 2, IsTunnelPort: true, VNI: 100},
+				{PortName: "vxlan", PortNo: 2, IsTunnelPort: true, VNI: 200},
+			},
+			toClient: [2]string{
+				"table=30,priority=100,in_port=2,tun_id=100,actions=push_vlan:0x8100,set_field:4097->vlan_vid,load:5->NXM_NX_REG1[],goto_table:40",
+				"table=30,priority=100,in_port=2,tun_id=200,actions=push_vlan:0x8100,set_field:4098->vlan_vid,load:5->NXM_NX_REG1[],goto_table:40",
+			},
+			toEndpoint: [2]string{
+				"table=30,priority=100,in_port=5,dl_vlan=1,actions=strip_vlan,set_field:100->tun_id,load:2->NXM_NX_REG1[],goto_table:40",
+				"table=30,priority=100,in_port=5,dl_vlan=2,actions=strip_vlan,set_field:200->tun_id,load:2->NXM_NX_REG1[],goto_table:40",
+			},
+			deleteMatch: [2]string{"in_port=5,dl_vlan=1", "in_port=5,dl_vlan=2"},
+		},
+	} {
+		t.Run(sample.name, func(t *testing.T) {
+			var connMatches [2]map[string]string
+			for i, endpoint := range sample.endpoints {
+				client := vhostUserPort(uint32(i + 1))
+				toClient, toEndpoint := sample.directions(endpoint, client)
+				connMatches[i] = flowMatches(toClient, toEndpoint)
+
+				require.Contains(t, toClient.flows(), sample.toClient[i])
+				require.Contains(t, toEndpoint.flows(), sample.toEndpoint[i])
+				require.Equal(t, sample.deleteMatch[i], portMatch(client))
+			}
+			// the flows of a connection must not replace the ones of the other connection
+			for match := range connMatches[0] {
+				require.NotContains(t, connMatches[1], match)
+			}
+		})
+	}
+}
+
+func TestVhostUserHairpinCrossConnect(t *testing.T) {
+	toClient, toEndpoint := getLocalCrossConnectDirections(vhostUserPort(2), vhostUserPort(1), &crossConnectFeatures{})
+	require.Contains(t, toClient.flows(),
+		"table=30,priority=100,in_port=5,dl_vlan=2,actions=strip_vlan,push_vlan:0x8100,set_field:4097->vlan_vid,in_port")
+	require.Contains(t, toEndpoint.flows(),
+		"table=30,priority=100,in_port=5,dl_vlan=1,actions=strip_vlan,push_vlan:0x8100,set_field:4098->vlan_vid,in_port")
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// createQinQCrossConnect cross connects an endpoint port carrying 802.1ad service VLAN (and optionally 802.1Q
+// customer VLAN) with either a local client port or a tunnel port. The customer VLAN can only be matched once
+// the service VLAN is popped, so the packets of the endpoint are decapsulated in the classification stage and
+// handed over to the output stage right away.
+func createQinQCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo,
+	clientOvsPortInfo *ifnames.OvsPortInfo, features *crossConnectFeatures) error {
+	sampling := features.sampling
+	sVlanID, cVlanID := endpointOvsPortInfo.ServiceVlanID, endpointOvsPortInfo.VlanID
+	if err := ovsutil.EnableDoubleTagging(); err != nil {
+		logger.Errorf("Failed to enable double tagging for service VLAN %d: %v", sVlanID, err)
+		return err
+	}
+
+	toEndpoint := getQinQToEndpointDirection(endpointOvsPortInfo, clientOvsPortInfo, features)
+	toClientActions := vlanActions(0, clientOvsPortInfo.VlanID,
+		ovsutil.SetOutputPortAction(clientOvsPortInfo.PortNo), ovsutil.GotoTableAction(ovsutil.OutputTable))
+	if clientOvsPortInfo.IsTunnelPort {
+		toClientActions = fmt.Sprintf("set_field:%d->tun_id,%s", clientOvsPortInfo.VNI, toClientActions)
+	}
+	if sampling != nil {
+		toClientActions = sampling.action + "," + toClientActions
+	}
+	ofRulesToClient := ovsutil.QinQPopFlows(endpointOvsPortInfo.PortNo, sVlanID, cVlanID, toClientActions)
+
+	// the meter and the cookie go to the last rule, as the service VLAN rule may be shared by customer VLANs
+	last := len(ofRulesToClient) - 1
+	ofRulesToClient[last] = withMeter(ofRulesToClient[last], endpointOvsPortInfo.MeterID)
+	ofRulesToClient = append(ofRulesToClient[:last], sampling.withCookie(ofRulesToClient[last:])...)
+
+	for _, ofRule := range ofRulesToClient {
+		if err := addFlow(logger, bridgeName, endpointOvsPortInfo.PortName, ofRule); err != nil {
+			return err
+		}
+	}
+	if err := toEndpoint.addFlows(logger, bridgeName); err != nil {
+		return err
+	}
+
+	endpointOvsPortInfo.IsCrossConnected = true
+	clientOvsPortInfo.IsCrossConnected = true
+	clientOvsPortInfo.IsPortSecured = toEndpoint.security.isSet()
+
+	return nil
+}
+
+// getQinQToEndpointDirection returns the direction from the client port, either a local or a tunnel port. The service
+// VLAN trunk carries the traffic of many connections, so only a local client port may be secured.
+func getQinQToEndpointDirection(endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo,
+	features *crossConnectFeatures) *crossConnectDirection {
+	toEndpoint := &crossConnectDirection{
+		match:    portMatch(clientOvsPortInfo) + ",",
+		portName: clientOvsPortInfo.PortName,
+		meterID:  clientOvsPortInfo.MeterID,
+		encapActions: vlanActions(clientOvsPortInfo.VlanID, 0,
+			ovsutil.QinQPushActions(endpointOvsPortInfo.ServiceVlanID, endpointOvsPortInfo.VlanID)),
+		outPortNo:  endpointOvsPortInfo.PortNo,
+		sampling:   features.sampling,
+		toEndpoint: true,
+	}
+	if clientOvsPortInfo.IsTunnelPort {
+		toEndpoint.match = fmt.Sprintf("in_port=%d,tun_id=%d,", clientOvsPortInfo.PortNo, clientOvsPortInfo.VNI)
+	} else if security := features.security; security != nil {
+		toEndpoint.security = security.client
+	}
+	return toEndpoint
+}
+
+func deleteQinQCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo,
+	clientOvsPortInfo *ifnames.OvsPortInfo) error {
+	if err := ovsutil.DeleteQinQPopFlows(bridgeName, endpointOvsPortInfo.PortNo, endpointOvsPortInfo.ServiceVlanID,
+		endpointOvsPortInfo.VlanID); err != nil {
+		logger.Errorf("Failed to delete flow on %s for port %s, error: %v", bridgeName, endpointOvsPortInfo.PortName, err)
+		return err
+	}
+
+	ofMatch := portMatch(clientOvsPortInfo)
+	if clientOvsPortInfo.IsTunnelPort {
+		ofMatch = fmt.Sprintf("in_port=%d,tun_id=%d", clientOvsPortInfo.PortNo, clientOvsPortInfo.VNI)
+	}
+	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, ofMatch)
+	if err != nil {
+		logger.Errorf("Failed to delete flow on %s for port "+
+			"%s, stdout: %q, stderr: %q, error: %v", bridgeName, clientOvsPortInfo.PortName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to delete flow on %s for port %s, stdout: %q, stderr: %q", bridgeName, clientOvsPortInfo.PortName, stdout, stderr)
+	}
+	return nil
+}
+
+func addFlow(logger log.Logger, bridgeName, portName, ofRule string) error {
+	stdout, stderr, err := util.RunOVSOfctl("add-flow", "-OOpenflow13", bridgeName, ofRule)
+	if err != nil {
+		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
+			" stderr: %s, error: %v", bridgeName, portName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to add flow on %s for port %s stdout: %s stderr: %s", bridgeName, portName, stdout, stderr)
+	}
+	if stderr != "" {
+		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
+			" stderr: %s", bridgeName, portName, stdout, stderr)
+	}
+	return nil
+}
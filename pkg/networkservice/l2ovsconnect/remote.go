@@ -28,105 +28,81 @@ import (
 	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
 )
 
-func createRemoteCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo) error {
-	var (
-		ovsLocalPortNum, ovsTunnelPortNum int
-		ovsLocalPort, ovsTunnelPort       string
-		vni, vlanID                       uint32
-	)
-	if endpointOvsPortInfo.IsTunnelPort {
-		ovsLocalPortNum = clientOvsPortInfo.PortNo
-		ovsLocalPort = clientOvsPortInfo.PortName
-		ovsTunnelPortNum = endpointOvsPortInfo.PortNo
-		ovsTunnelPort = endpointOvsPortInfo.PortName
-		vni = endpointOvsPortInfo.VNI
-	} else {
-		ovsLocalPortNum = endpointOvsPortInfo.PortNo
-		ovsLocalPort = endpointOvsPortInfo.PortName
-		vlanID = endpointOvsPortInfo.VlanID
-		ovsTunnelPortNum = clientOvsPortInfo.PortNo
-		ovsTunnelPort = clientOvsPortInfo.PortName
-		vni = clientOvsPortInfo.VNI
-	}
-
-	var ofRuleFrom, ofRuleTo string
-	if vlanID > 0 {
-		ofRuleFrom = fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%d,actions=strip_vlan,set_field:%d->tun_id,output:%d",
-			ovsLocalPortNum, vlanID, vni, ovsTunnelPortNum)
-		ofRuleTo = fmt.Sprintf("priority=100,in_port=%d,tun_id=%d,actions=push_vlan:0x8100,set_field:%d->vlan_vid,output:%d",
-			ovsTunnelPortNum, vni, vlanID+4096, ovsLocalPortNum)
-	} else {
-		ofRuleFrom = fmt.Sprintf("priority=100,in_port=%d,actions=set_field:%d->tun_id,output:%d",
-			ovsLocalPortNum, vni, ovsTunnelPortNum)
-		ofRuleTo = fmt.Sprintf("priority=100,in_port=%d,tun_id=%d,actions=output:%d", ovsTunnelPortNum, vni, ovsLocalPortNum)
-	}
-	stdout, stderr, err := util.RunOVSOfctl("add-flow", "-OOpenflow13", bridgeName, ofRuleFrom)
-	if err != nil {
-		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
-			" stderr: %s, error: %v", bridgeName, ovsLocalPort, stdout, stderr, err)
-		return errors.Wrapf(err, "failed to add flow on %s for port %s stdout: %s stderr: %s", bridgeName, ovsLocalPort, stdout, stderr)
+func createRemoteCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo,
+	features *crossConnectFeatures) error {
+	fromLocal, fromTunnel := getRemoteCrossConnectDirections(endpointOvsPortInfo, clientOvsPortInfo, features)
+	if err := fromLocal.addFlows(logger, bridgeName); err != nil {
+		return err
 	}
-	if stderr != "" {
-		logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
-			" stderr: %s", bridgeName, ovsLocalPort, stdout, stderr)
-	}
-
-	stdout, stderr, err = util.RunOVSOfctl("add-flow", "-OOpenflow13", bridgeName, ofRuleTo)
-	if err != nil {
-		logger.Errorf("Failed to add tunnel flow on %s for port %s stdout: %s"+
-			" stderr: %s, error: %v", bridgeName, ovsTunnelPort, stdout, stderr, err)
-		return errors.Wrapf(err, "failed to add tunnel flow on %s for port %s stdout: %s, stderr: %s", bridgeName, ovsTunnelPort, stdout, stderr)
-	}
-
-	if stderr != "" {
-		logger.Errorf("Failed to add tunnel flow on %s for port %s stdout: %s"+
-			" stderr: %s", bridgeName, ovsTunnelPort, stdout, stderr)
+	if err := fromTunnel.addFlows(logger, bridgeName); err != nil {
+		return err
 	}
 
 	endpointOvsPortInfo.IsCrossConnected = true
 	clientOvsPortInfo.IsCrossConnected = true
+	localPort, _ := getRemotePorts(endpointOvsPortInfo, clientOvsPortInfo)
+	localPort.IsPortSecured = fromLocal.security.isSet()
 
 	return nil
 }
 
-func deleteRemoteCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo) error {
-	var (
-		ovsLocalPortNum, ovsTunnelPortNum int
-		ovsLocalPort, ovsTunnelPort       string
-		vni, vlanID                       uint32
-	)
-	if endpointOvsPortInfo.IsTunnelPort {
-		ovsLocalPortNum = clientOvsPortInfo.PortNo
-		ovsLocalPort = clientOvsPortInfo.PortName
-		ovsTunnelPortNum = endpointOvsPortInfo.PortNo
-		ovsTunnelPort = endpointOvsPortInfo.PortName
-		vni = endpointOvsPortInfo.VNI
-	} else {
-		ovsLocalPortNum = endpointOvsPortInfo.PortNo
-		ovsLocalPort = endpointOvsPortInfo.PortName
-		vlanID = endpointOvsPortInfo.VlanID
-		ovsTunnelPortNum = clientOvsPortInfo.PortNo
-		ovsTunnelPort = clientOvsPortInfo.PortName
-		vni = clientOvsPortInfo.VNI
+// getRemoteCrossConnectDirections returns the directions from the local port and from the tunnel port, the local
+// port is the nsc one when the endpoint is reached through the tunnel. Only the local port may be secured, the
+// tunnel carries the traffic of the remote one.
+func getRemoteCrossConnectDirections(endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo,
+	features *crossConnectFeatures) (fromLocal, fromTunnel *crossConnectDirection) {
+	localPort, tunnelPort := getRemotePorts(endpointOvsPortInfo, clientOvsPortInfo)
+	fromLocal = &crossConnectDirection{
+		match:        portMatch(localPort) + ",",
+		portName:     localPort.PortName,
+		meterID:      localPort.MeterID,
+		encapActions: vlanActions(localPort.VlanID, 0, fmt.Sprintf("set_field:%d->tun_id", tunnelPort.VNI)),
+		outPortNo:    tunnelPort.PortNo,
+		acl:          features.acl,
+		sampling:     features.sampling,
+		toEndpoint:   endpointOvsPortInfo.IsTunnelPort,
+	}
+	fromTunnel = &crossConnectDirection{
+		match:        fmt.Sprintf("in_port=%d,tun_id=%d,", tunnelPort.PortNo, tunnelPort.VNI),
+		portName:     tunnelPort.PortName,
+		meterID:      tunnelPort.MeterID,
+		encapActions: vlanActions(0, localPort.VlanID),
+		outPortNo:    localPort.PortNo,
+		acl:          features.acl,
+		sampling:     features.sampling,
+		toEndpoint:   !endpointOvsPortInfo.IsTunnelPort,
 	}
-	var ofMatch string
-	if vlanID > 0 {
-		ofMatch = fmt.Sprintf("in_port=%d,dl_vlan=%d", ovsLocalPortNum, vlanID)
-	} else {
-		ofMatch = fmt.Sprintf("in_port=%d", ovsLocalPortNum)
+	if security := features.security; security != nil {
+		fromLocal.security = security.endpoint
+		if endpointOvsPortInfo.IsTunnelPort {
+			fromLocal.security = security.client
+		}
+	}
+	return fromLocal, fromTunnel
+}
+
+func getRemotePorts(endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo) (localPort, tunnelPort *ifnames.OvsPortInfo) {
+	if endpointOvsPortInfo.IsTunnelPort {
+		return clientOvsPortInfo, endpointOvsPortInfo
 	}
-	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, ofMatch)
+	return endpointOvsPortInfo, clientOvsPortInfo
+}
+
+func deleteRemoteCrossConnect(logger log.Logger, bridgeName string, endpointOvsPortInfo, clientOvsPortInfo *ifnames.OvsPortInfo) error {
+	localPort, tunnelPort := getRemotePorts(endpointOvsPortInfo, clientOvsPortInfo)
+	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, portMatch(localPort))
 	if err != nil {
 		logger.Errorf("Failed to delete flow on %s for port "+
-			"%s, stdout: %q, stderr: %q, error: %v", bridgeName, ovsLocalPort, stdout, stderr, err)
-		return errors.Wrapf(err, "Failed to delete flow on %s for port %s, stdout: %q, stderr: %q", bridgeName, ovsLocalPort, stdout, stderr)
+			"%s, stdout: %q, stderr: %q, error: %v", bridgeName, localPort.PortName, stdout, stderr, err)
+		return errors.Wrapf(err, "Failed to delete flow on %s for port %s, stdout: %q, stderr: %q", bridgeName, localPort.PortName, stdout, stderr)
 	}
 
-	stdout, stderr, err = util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, fmt.Sprintf("in_port=%d,tun_id=%d", ovsTunnelPortNum, vni))
+	vni := tunnelPort.VNI
+	stdout, stderr, err = util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, fmt.Sprintf("in_port=%d,tun_id=%d", tunnelPort.PortNo, vni))
 	if err != nil {
 		logger.Errorf("Failed to delete flow on %s for port "+
-			"%s on VNI %d, stdout: %q, stderr: %q, error: %v", bridgeName, ovsTunnelPort, vni, stdout, stderr, err)
-		return errors.Wrapf(err, "failed to delete flow on %s for port %s on VNI %d, stdout: %q, stderr: %q", bridgeName, ovsTunnelPort, vni, stdout, stderr)
+			"%s on VNI %d, stdout: %q, stderr: %q, error: %v", bridgeName, tunnelPort.PortName, vni, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to delete flow on %s for port %s on VNI %d, stdout: %q, stderr: %q", bridgeName, tunnelPort.PortName, vni, stdout, stderr)
 	}
 
 	return nil
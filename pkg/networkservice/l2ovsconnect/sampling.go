@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// flowSampling samples the packets of a connection to the IPFIX flow sample collector set, the samples carry
+// the observation point ID of the connection which is also the cookie of its flows
+type flowSampling struct {
+	action string
+	cookie uint32
+}
+
+func newFlowSampling(logger log.Logger, conn *networkservice.Connection, sampling, obsDomainID, obsPointID uint32) *flowSampling {
+	logger.Infof("connection %s of network service %s is sampled with observation point %d",
+		conn.GetId(), conn.GetNetworkService(), obsPointID)
+	return &flowSampling{
+		action: ovsutil.FlowSampleAction(sampling, obsDomainID, obsPointID),
+		cookie: obsPointID,
+	}
+}
+
+// obsPoints allocates the observation point IDs of the sampled connections. The ID is derived from the connection ID,
+// see ovsutil.ObservationPointID, an ID colliding with the one of another connection is replaced by the next free one.
+type obsPoints struct {
+	mutex   sync.Mutex
+	connIDs map[uint32]string
+}
+
+func newObsPoints() *obsPoints {
+	return &obsPoints{connIDs: make(map[uint32]string)}
+}
+
+// allocate returns the observation point ID of the connection and sets it on its ports
+func (p *obsPoints) allocate(connID string, ports ...*ifnames.OvsPortInfo) uint32 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	id := ovsutil.ObservationPointID(connID)
+	for {
+		if owner, taken := p.connIDs[id]; id != 0 && (!taken || owner == connID) {
+			break
+		}
+		id++
+	}
+	p.connIDs[id] = connID
+	for _, port := range ports {
+		port.ObsPointID = id
+	}
+	return id
+}
+
+// free releases the observation point ID of the connection ports
+func (p *obsPoints) free(ports ...*ifnames.OvsPortInfo) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, port := range ports {
+		delete(p.connIDs, port.ObsPointID)
+		port.ObsPointID = 0
+	}
+}
+
+// withCookie sets the cookie of the connection on the flows, they are returned as is when sampling is nil
+func (s *flowSampling) withCookie(flows []string) []string {
+	if s == nil {
+		return flows
+	}
+	for i, flow := range flows {
+		flows[i] = fmt.Sprintf("cookie=0x%x,%s", s.cookie, flow)
+	}
+	return flows
+}
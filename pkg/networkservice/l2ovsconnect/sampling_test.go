@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l2ovsconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+func TestObsPointsCollision(t *testing.T) {
+	p := newObsPoints()
+	id := ovsutil.ObservationPointID("conn-1")
+	// another connection hashed to the same ID
+	p.connIDs[id] = "conn-2"
+
+	port := &ifnames.OvsPortInfo{}
+	require.Equal(t, id+1, p.allocate("conn-1", port))
+	require.Equal(t, id+1, port.ObsPointID)
+	// the ID of an established connection is kept on refresh
+	require.Equal(t, id+1, p.allocate("conn-1"))
+
+	p.free(port)
+	require.Zero(t, port.ObsPointID)
+	require.Equal(t, map[uint32]string{id: "conn-2"}, p.connIDs)
+}
+
+func TestObsPointsNextFree(t *testing.T) {
+	p := newObsPoints()
+	id := ovsutil.ObservationPointID("conn-1")
+	p.connIDs[id] = "conn-2"
+	p.connIDs[id+1] = "conn-3"
+
+	require.Equal(t, id+2, p.allocate("conn-1"))
+}
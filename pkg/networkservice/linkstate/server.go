@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package linkstate provides chain element which watches the links of the connection ovs ports and
+// reports the connection down on the monitor connection stream when a link is deleted or goes down
+package linkstate
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+type linkStateServer struct {
+	watcher *watcher
+}
+
+// NewServer - returns a server chain element reporting connection down when the veth end or VF
+// representor of either side of the connection is deleted or goes down. Link updates are watched
+// until chainCtx is done.
+func NewServer(chainCtx context.Context) networkservice.NetworkServiceServer {
+	return &linkStateServer{watcher: newWatcher(chainCtx)}
+}
+
+func (l *linkStateServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var linkNames []string
+	for _, isClient := range []bool{metadata.IsClient(l), true} {
+		// tunnel ports are shared by the connections, their state is not bound to a single connection
+		if ovsPortInfo, ok := ifnames.Load(ctx, isClient); ok && !ovsPortInfo.IsTunnelPort {
+			linkNames = append(linkNames, ovsPortInfo.PortName)
+			if ovsPortInfo.LinkedPortName != "" {
+				linkNames = append(linkNames, ovsPortInfo.LinkedPortName)
+			}
+		}
+	}
+	if len(linkNames) == 0 {
+		return conn, nil
+	}
+	eventConsumer, ok := monitor.LoadEventConsumer(ctx, metadata.IsClient(l))
+	if !ok {
+		log.FromContext(ctx).WithField("linkStateServer", "Request").Warnf("no monitor event consumer, link state of %s is not monitored",
+			conn.GetId())
+		return conn, nil
+	}
+	l.watcher.add(conn, linkNames, eventConsumer)
+
+	return conn, nil
+}
+
+func (l *linkStateServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	l.watcher.remove(conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package linkstate
+
+import (
+	"context"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/vishvananda/netlink"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// interfaceStatePollInterval - the interval of polling the link state of the ovs interfaces
+const interfaceStatePollInterval = 5 * time.Second
+
+type connectionInfo struct {
+	conn          *networkservice.Connection
+	linkNames     []string
+	eventConsumer monitor.EventConsumer
+	isDown        bool
+}
+
+// watcher maps netlink link updates of the ovs ports to the connections using them
+type watcher struct {
+	mutex       sync.Mutex
+	connections map[string]*connectionInfo
+	// upLinks - the links of the connections seen up in the ovs interface table
+	upLinks map[string]bool
+}
+
+func newWatcher(chainCtx context.Context) *watcher {
+	w := &watcher{connections: make(map[string]*connectionInfo), upLinks: make(map[string]bool)}
+	go w.pollInterfaceStates(chainCtx)
+
+	logger := log.FromContext(chainCtx).WithField("linkStateWatcher", "subscribe")
+
+	linkUpdateCh := make(chan netlink.LinkUpdate)
+	err := netlink.LinkSubscribeWithOptions(linkUpdateCh, chainCtx.Done(), netlink.LinkSubscribeOptions{
+		ErrorCallback: func(err error) {
+			logger.Errorf("link subscription error: %v", err)
+		},
+	})
+	if err != nil {
+		logger.Errorf("failed to subscribe link updates, link state is not monitored: %v", err)
+		return w
+	}
+	go func() {
+		for linkUpdate := range linkUpdateCh {
+			if isLinkDown(&linkUpdate) {
+				w.linkDown(chainCtx, linkUpdate.Attrs().Name)
+			}
+		}
+	}()
+	return w
+}
+
+// add starts watching the links of the connection, a connection reported as down is watched again
+// once it is refreshed
+func (w *watcher) add(conn *networkservice.Connection, linkNames []string, eventConsumer monitor.EventConsumer) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.connections[conn.GetId()] = &connectionInfo{
+		conn:          conn.Clone(),
+		linkNames:     linkNames,
+		eventConsumer: eventConsumer,
+	}
+}
+
+func (w *watcher) remove(connID string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.connections, connID)
+}
+
+func (w *watcher) linkDown(ctx context.Context, linkName string) {
+	// the events are sent without holding the mutex, the event consumer may call back into the chain
+	for _, info := range w.setDown(linkName) {
+		conn := info.conn.Clone()
+		conn.State = networkservice.State_DOWN
+		logger := log.FromContext(ctx).WithField("linkStateWatcher", "linkDown").WithField("connectionID", conn.GetId())
+		logger.Warnf("link %s is down, reporting connection down", linkName)
+		err := info.eventConsumer.Send(&networkservice.ConnectionEvent{
+			Type:        networkservice.ConnectionEventType_UPDATE,
+			Connections: map[string]*networkservice.Connection{conn.GetId(): conn},
+		})
+		if err != nil {
+			logger.Errorf("failed to send connection down event: %v", err)
+		}
+	}
+}
+
+// setDown marks the connections of the link down and returns the ones which were not down yet
+func (w *watcher) setDown(linkName string) []*connectionInfo {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	var down []*connectionInfo
+	for _, info := range w.connections {
+		if info.isDown || !contains(info.linkNames, linkName) {
+			continue
+		}
+		info.isDown = true
+		down = append(down, info)
+	}
+	return down
+}
+
+// pollInterfaceStates reports the links going down in the ovs interface table, the ports without a kernel netdev,
+// e.g. the DPDK and the vhost-user ports, are not reported by netlink. A link is reported down only after it was
+// seen up, since the vhost-user ports are down until the application connects to their socket.
+func (w *watcher) pollInterfaceStates(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("linkStateWatcher", "pollInterfaceStates")
+	ticker := time.NewTicker(interfaceStatePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if w.isEmpty() {
+			continue
+		}
+		states, err := ovsutil.GetInterfaceLinkStates()
+		if err != nil {
+			logger.Warnf("failed to get the link state of the ovs interfaces: %v", err)
+			continue
+		}
+		for _, linkName := range w.updateLinkStates(states) {
+			w.linkDown(ctx, linkName)
+		}
+	}
+}
+
+func (w *watcher) isEmpty() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.connections) == 0
+}
+
+// updateLinkStates updates the links of the connections seen up and returns the ones which went down
+func (w *watcher) updateLinkStates(states map[string]bool) []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	linkNames := make(map[string]bool)
+	for _, info := range w.connections {
+		for _, linkName := range info.linkNames {
+			linkNames[linkName] = true
+		}
+	}
+	var down []string
+	for linkName := range linkNames {
+		switch {
+		case states[linkName]:
+			w.upLinks[linkName] = true
+		case w.upLinks[linkName]:
+			delete(w.upLinks, linkName)
+			down = append(down, linkName)
+		}
+	}
+	for linkName := range w.upLinks {
+		if !linkNames[linkName] {
+			delete(w.upLinks, linkName)
+		}
+	}
+	return down
+}
+
+func isLinkDown(linkUpdate *netlink.LinkUpdate) bool {
+	if linkUpdate.Header.Type == syscall.RTM_DELLINK {
+		return true
+	}
+	attrs := linkUpdate.Attrs()
+	if attrs.Flags&net.FlagUp == 0 {
+		return true
+	}
+	switch attrs.OperState {
+	case netlink.OperDown, netlink.OperLowerLayerDown, netlink.OperNotPresent:
+		return true
+	default:
+		return false
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
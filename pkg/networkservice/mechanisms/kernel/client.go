@@ -44,16 +44,16 @@ import (
 )
 
 type kernelClient struct {
-	bridgeName           string
-	parentIfmutex        sync.Locker
-	parentIfRefCountMap  map[string]int
-	serviceToparentIfMap map[string]string
+	bridgeName          string
+	parentIfmutex       sync.Locker
+	parentIfRefCountMap map[string]int
+	trunkParentIfMap    map[string]string
 }
 
 // NewClient returns a client chain element implementing kernel mechanism with veth pair or smartvf
 func NewClient(bridgeName string, mutex sync.Locker, parentIfRefCountMap map[string]int) networkservice.NetworkServiceClient {
 	return &kernelClient{bridgeName: bridgeName, parentIfmutex: mutex, parentIfRefCountMap: parentIfRefCountMap,
-		serviceToparentIfMap: make(map[string]string)}
+		trunkParentIfMap: make(map[string]string)}
 }
 
 func (c *kernelClient) Request(
@@ -81,23 +81,19 @@ func (c *kernelClient) Request(
 	}
 
 	c.parentIfmutex.Lock()
-	defer c.parentIfmutex.Unlock()
-	_, exists := conn.GetMechanism().GetParameters()[common.PCIAddressKey]
-	if exists {
-		if err = setupVF(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, metadata.IsClient(c)); err != nil {
-			closeCtx, cancelClose := postponeCtxFunc()
-			defer cancelClose()
-			if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
-				logger.Errorf("failed to close failed connection: %s %s", conn.GetId(), closeErr.Error())
-			}
-		}
-	} else {
-		if err = setupVeth(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, c.serviceToparentIfMap, metadata.IsClient(c)); err != nil {
-			closeCtx, cancelClose := postponeCtxFunc()
-			defer cancelClose()
-			if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
-				logger.Errorf("failed to close failed connection: %s %s", conn.GetId(), closeErr.Error())
-			}
+	if _, exists := conn.GetMechanism().GetParameters()[common.PCIAddressKey]; exists {
+		err = setupVF(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, metadata.IsClient(c))
+	} else if err = setupVeth(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, c.trunkParentIfMap, metadata.IsClient(c)); err != nil {
+		// veth port info is not stored yet, so release the (possibly shared) parent interface here.
+		_ = resetVeth(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, c.trunkParentIfMap, false, metadata.IsClient(c))
+	}
+	c.parentIfmutex.Unlock()
+
+	if err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			logger.Errorf("failed to close failed connection: %s %s", conn.GetId(), closeErr.Error())
 		}
 	}
 
@@ -116,7 +112,7 @@ func (c *kernelClient) Close(ctx context.Context, conn *networkservice.Connectio
 		if exists {
 			// ovsPortInfo.IsL2Connect is always false for endpoint ovs port
 			if !ovsPortInfo.IsVfRepresentor {
-				kernelMechErr = resetVeth(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, c.serviceToparentIfMap, ovsPortInfo.IsL2Connect, metadata.IsClient(c))
+				kernelMechErr = resetVeth(ctx, logger, conn, c.bridgeName, c.parentIfRefCountMap, c.trunkParentIfMap, ovsPortInfo.IsL2Connect, metadata.IsClient(c))
 			} else {
 				kernelMechErr = resetVF(logger, ovsPortInfo, c.parentIfRefCountMap, c.bridgeName, ovsPortInfo.IsL2Connect)
 			}
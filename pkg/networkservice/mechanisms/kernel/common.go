@@ -47,7 +47,7 @@ const (
 )
 
 func setupVeth(ctx context.Context, logger log.Logger, conn *networkservice.Connection, bridgeName string,
-	parentIfRefCountMap map[string]int, serviceToparentIfMap map[string]string, isClient bool) error {
+	parentIfRefCountMap map[string]int, trunkParentIfMap map[string]string, isClient bool) error {
 	var mechanism *kernel.Mechanism
 	if mechanism = kernel.ToMechanism(conn.GetMechanism()); mechanism == nil {
 		return nil
@@ -56,11 +56,11 @@ func setupVeth(ctx context.Context, logger log.Logger, conn *networkservice.Conn
 		return nil
 	}
 
-	serviceName := conn.GetNetworkService()
+	trunkParentKey := getTrunkParentKey(conn, isClient)
 
 	var hostIfName, contIfName string
 	if mechanism.GetVLAN() > 0 {
-		if parentIfName, exists := serviceToparentIfMap[serviceName]; exists {
+		if parentIfName, exists := trunkParentIfMap[trunkParentKey]; exists {
 			hostIfName = parentIfName
 		}
 	}
@@ -78,12 +78,12 @@ func setupVeth(ctx context.Context, logger log.Logger, conn *networkservice.Conn
 		if err := createInterfaces(contIfName, hostIfName); err != nil {
 			return err
 		}
+		if mechanism.GetVLAN() > 0 {
+			trunkParentIfMap[trunkParentKey] = hostIfName
+		}
 		if err := SetInterfacesUp(logger, contIfName, hostIfName); err != nil {
 			return err
 		}
-		if mechanism.GetVLAN() > 0 {
-			serviceToparentIfMap[serviceName] = hostIfName
-		}
 	}
 
 	if _, exists := parentIfRefCountMap[hostIfName]; !exists {
@@ -106,23 +106,23 @@ func setupVeth(ctx context.Context, logger log.Logger, conn *networkservice.Conn
 
 	vfconfig.Store(ctx, isClient, &vfconfig.VFConfig{VFInterfaceName: contIfName})
 	ifnames.Store(ctx, isClient, &ifnames.OvsPortInfo{PortName: hostIfName, PortNo: portNo,
-		VlanID: mechanism.GetVLAN(), IsTunnelPort: false})
+		VlanID: mechanism.GetVLAN(), ServiceVlanID: ovsutil.GetServiceVlanID(mechanism.GetParameters()), IsTunnelPort: false})
 
 	return nil
 }
 
 func resetVeth(ctx context.Context, logger log.Logger, conn *networkservice.Connection, bridgeName string,
-	parentIfRefCountMap map[string]int, serviceToparentIfMap map[string]string, isL2Connect, isClient bool) error {
+	parentIfRefCountMap map[string]int, trunkParentIfMap map[string]string, isL2Connect, isClient bool) error {
 	var mechanism *kernel.Mechanism
 	if mechanism = kernel.ToMechanism(conn.GetMechanism()); mechanism == nil {
 		return nil
 	}
 
-	serviceName := conn.GetNetworkService()
+	trunkParentKey := getTrunkParentKey(conn, isClient)
 
 	var ifaceName string
 	if mechanism.GetVLAN() > 0 {
-		if parentIfName, exists := serviceToparentIfMap[serviceName]; exists {
+		if parentIfName, exists := trunkParentIfMap[trunkParentKey]; exists {
 			ifaceName = parentIfName
 		} else {
 			return errors.Errorf("parent interface not found for connection %v", conn)
@@ -154,26 +154,40 @@ func resetVeth(ctx context.Context, logger log.Logger, conn *networkservice.Conn
 		}
 		/* Get a link object for the interface */
 		ifaceLink, err := netlink.LinkByName(ifaceName)
-		if err != nil {
-			if strings.Contains(err.Error(), "Link not found") {
-				// link is aleady deleted
-				return nil
+		switch {
+		case err == nil:
+			/* Delete the VETH pair - host namespace */
+			if err := netlink.LinkDel(ifaceLink); err != nil {
+				return errors.Errorf("local: failed to delete the VETH pair - %v", err)
 			}
+		case strings.Contains(err.Error(), "Link not found"):
+			// link is aleady deleted, its bookkeeping still has to be released
+		default:
 			return errors.Errorf("failed to get link for %q - %v", ifaceName, err)
 		}
-
-		/* Delete the VETH pair - host namespace */
-		if err := netlink.LinkDel(ifaceLink); err != nil {
-			return errors.Errorf("local: failed to delete the VETH pair - %v", err)
-		}
 		delete(parentIfRefCountMap, ifaceName)
-		delete(serviceToparentIfMap, serviceName)
+		if mechanism.GetVLAN() > 0 {
+			delete(trunkParentIfMap, trunkParentKey)
+		}
 	}
 
 	vfconfig.Delete(ctx, isClient)
 	return nil
 }
 
+// getTrunkParentKey returns the key of the parent interface shared by vlan sub-interfaces. The parent
+// interface lives in a pod network namespace, so it can only be shared by the connections of the same
+// network service in the same pod (ns client for server side, ns endpoint for client side connection).
+func getTrunkParentKey(conn *networkservice.Connection, isClient bool) string {
+	var podName string
+	if isClient {
+		podName = conn.GetNetworkServiceEndpointName()
+	} else if segments := conn.GetPath().GetPathSegments(); len(segments) > 0 {
+		podName = segments[0].GetName()
+	}
+	return conn.GetNetworkService() + "/" + podName
+}
+
 func createInterfaces(ifName, ovSPortName string) error {
 	/* Create the VETH pair - host namespace */
 	if err := netlink.LinkAdd(newVETH(ifName, ovSPortName)); err != nil {
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernel
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Mellanox/sriovnet"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+const (
+	netSysDir        = "/sys/class/net"
+	sfNumFile        = "sfnum"
+	physPortNameFile = "phys_port_name"
+	pciBusName       = "pci"
+)
+
+// getRepresentor returns the representor net device of the smart VF or scalable function (SF)
+// described by vfConfig.
+func getRepresentor(vfConfig *vfconfig.VFConfig) (string, error) {
+	sfNum, isSF, err := getSFNum(vfConfig.VFInterfaceName)
+	if err != nil {
+		return "", err
+	}
+	if isSF {
+		return getSFRepresentor(vfConfig.PFInterfaceName, sfNum)
+	}
+	return sriovnet.GetVfRepresentor(vfConfig.PFInterfaceName, vfConfig.VFNum)
+}
+
+// getSFNum checks whether the given net device is backed by an auxiliary SF device and returns its SF number.
+func getSFNum(ifName string) (sfNum int, isSF bool, err error) {
+	if ifName == "" {
+		return 0, false, nil
+	}
+	content, err := os.ReadFile(filepath.Clean(filepath.Join(netSysDir, ifName, "device", sfNumFile)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrapf(err, "failed to read SF number of %s", ifName)
+	}
+	sfNum, err = strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid SF number of %s", ifName)
+	}
+	return sfNum, true, nil
+}
+
+// getSFRepresentor looks up devlink ports of the PF's eswitch for a PCI SF flavoured port whose
+// physical port name (pf<N>sf<M>) matches the given SF number.
+func getSFRepresentor(pfIfName string, sfNum int) (string, error) {
+	pfPCIAddress, err := os.Readlink(filepath.Join(netSysDir, pfIfName, "device"))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find PCI address of uplink %s", pfIfName)
+	}
+	pfPCIAddress = filepath.Base(pfPCIAddress)
+
+	ports, err := netlink.DevLinkGetAllPortList()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get devlink ports")
+	}
+	sfSuffix := fmt.Sprintf("sf%d", sfNum)
+	for _, port := range ports {
+		if port.BusName != pciBusName || port.DeviceName != pfPCIAddress ||
+			port.PortFlavour != nl.DEVLINK_PORT_FLAVOUR_PCI_SF || port.NetdeviceName == "" {
+			continue
+		}
+		physPortName, readErr := os.ReadFile(filepath.Clean(filepath.Join(netSysDir, port.NetdeviceName, physPortNameFile)))
+		if readErr != nil {
+			continue
+		}
+		if strings.HasSuffix(strings.TrimSpace(string(physPortName)), sfSuffix) {
+			return port.NetdeviceName, nil
+		}
+	}
+	return "", errors.Errorf("failed to find SF %d representor for uplink %s", sfNum, pfIfName)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/networkservicemesh/sdk-sriov/pkg/networkservice/common/resourcepool"
+	"github.com/networkservicemesh/sdk-sriov/pkg/sriov"
+	"github.com/pkg/errors"
+)
+
+const (
+	auxiliaryDevicesDir = "/sys/bus/auxiliary/devices"
+	// sfIOMMUGroup - the SFs have no IOMMU group of their own, no PCI function is bound for them
+	sfIOMMUGroup = ^uint(0)
+)
+
+type sfPCIPool struct {
+	resourcepool.PCIPool
+}
+
+// NewSFPCIPool returns a PCI pool which also hands out the scalable functions (SF) listed as virtual functions
+// of the physical functions in the sriov config, by their auxiliary device name, e.g. mlx5_core.sf.2. The
+// other functions are handed out by pciPool. The SFs are used with the kernel driver they are bound to.
+func NewSFPCIPool(pciPool resourcepool.PCIPool) resourcepool.PCIPool {
+	return &sfPCIPool{PCIPool: pciPool}
+}
+
+func (p *sfPCIPool) GetPCIFunction(pciAddr string) (sriov.PCIFunction, error) {
+	if !isSFDevice(pciAddr) {
+		return p.PCIPool.GetPCIFunction(pciAddr)
+	}
+	return &sfFunction{name: pciAddr}, nil
+}
+
+func (p *sfPCIPool) BindDriver(ctx context.Context, iommuGroup uint, driverType sriov.DriverType) error {
+	if iommuGroup != sfIOMMUGroup {
+		return p.PCIPool.BindDriver(ctx, iommuGroup, driverType)
+	}
+	if driverType != sriov.KernelDriver {
+		return errors.Errorf("scalable functions support the kernel driver only, not %v", driverType)
+	}
+	return nil
+}
+
+// sfFunction is a scalable function handed out as a PCI function
+type sfFunction struct {
+	name string
+}
+
+func (f *sfFunction) GetPCIAddress() string {
+	return f.name
+}
+
+func (f *sfFunction) GetNetInterfaceName() (string, error) {
+	entries, err := os.ReadDir(filepath.Join(auxiliaryDevicesDir, f.name, "net"))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find net interface of SF %s", f.name)
+	}
+	if len(entries) == 0 {
+		return "", errors.Errorf("SF %s has no net interface", f.name)
+	}
+	return entries[0].Name(), nil
+}
+
+func (f *sfFunction) GetIOMMUGroup() (uint, error) {
+	return sfIOMMUGroup, nil
+}
+
+// isSFDevice checks whether the name is the one of an auxiliary SF device
+func isSFDevice(name string) bool {
+	_, err := os.Stat(filepath.Join(auxiliaryDevicesDir, filepath.Base(name), sfNumFile))
+	return err == nil
+}
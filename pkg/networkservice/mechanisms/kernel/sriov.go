@@ -24,7 +24,6 @@ package kernel
 import (
 	"context"
 
-	"github.com/Mellanox/sriovnet"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/vfconfig"
@@ -50,11 +49,11 @@ func setupVF(ctx context.Context, logger log.Logger, conn *networkservice.Connec
 	if !exists {
 		return errors.New("vfconfig not found")
 	}
-	// get smart VF representor interface. This is a host net device which represents
+	// get smart VF (or SF) representor interface. This is a host net device which represents
 	// smart VF attached inside the container by device plugin. It can be considered
 	// as one end of veth pair whereas other end is smartVF. The VF representor would
 	// get added into ovs bridge for the control plane configuration.
-	vfRepresentor, err := sriovnet.GetVfRepresentor(vfConfig.PFInterfaceName, vfConfig.VFNum)
+	vfRepresentor, err := getRepresentor(vfConfig)
 	if err != nil {
 		return errors.Wrapf(err, "failed to find VF representor for uplink %s", vfConfig.PFInterfaceName)
 	}
@@ -76,7 +75,7 @@ func setupVF(ctx context.Context, logger log.Logger, conn *networkservice.Connec
 	}
 
 	ifnames.Store(ctx, isClient, &ifnames.OvsPortInfo{PortName: vfRepresentor, PortNo: portNo,
-		VlanID: mechanism.GetVLAN(), IsVfRepresentor: true})
+		VlanID: mechanism.GetVLAN(), ServiceVlanID: ovsutil.GetServiceVlanID(mechanism.GetParameters()), IsVfRepresentor: true})
 	return nil
 }
 
@@ -38,16 +38,16 @@ import (
 )
 
 type kernelVethServer struct {
-	bridgeName           string
-	parentIfmutex        sync.Locker
-	parentIfRefCountMap  map[string]int
-	serviceToparentIfMap map[string]string
+	bridgeName          string
+	parentIfmutex       sync.Locker
+	parentIfRefCountMap map[string]int
+	trunkParentIfMap    map[string]string
 }
 
 // NewVethServer - return a new Veth Server chain element for kernel mechanism
 func NewVethServer(bridgeName string, mutex sync.Locker, parentIfRefCountMap map[string]int) networkservice.NetworkServiceServer {
 	return &kernelVethServer{bridgeName: bridgeName, parentIfmutex: mutex, parentIfRefCountMap: parentIfRefCountMap,
-		serviceToparentIfMap: make(map[string]string)}
+		trunkParentIfMap: make(map[string]string)}
 }
 
 // NewClient create a kernel veth server chain element which would be useful to do network plumbing
@@ -59,8 +59,8 @@ func (k *kernelVethServer) Request(ctx context.Context, request *networkservice.
 
 	if !isEstablished {
 		k.parentIfmutex.Lock()
-		if err := setupVeth(ctx, logger, request.GetConnection(), k.bridgeName, k.parentIfRefCountMap, k.serviceToparentIfMap, metadata.IsClient(k)); err != nil {
-			_ = resetVeth(ctx, logger, request.GetConnection(), k.bridgeName, k.parentIfRefCountMap, k.serviceToparentIfMap, false, metadata.IsClient(k))
+		if err := setupVeth(ctx, logger, request.GetConnection(), k.bridgeName, k.parentIfRefCountMap, k.trunkParentIfMap, metadata.IsClient(k)); err != nil {
+			_ = resetVeth(ctx, logger, request.GetConnection(), k.bridgeName, k.parentIfRefCountMap, k.trunkParentIfMap, false, metadata.IsClient(k))
 			k.parentIfmutex.Unlock()
 			return nil, err
 		}
@@ -80,7 +80,7 @@ func (k *kernelVethServer) Request(ctx context.Context, request *networkservice.
 				request.GetConnection(),
 				k.bridgeName,
 				k.parentIfRefCountMap,
-				k.serviceToparentIfMap,
+				k.trunkParentIfMap,
 				false, metadata.IsClient(k),
 			); kernelServerErr != nil {
 				err = errors.Wrapf(err, "connection closed with error: %s", kernelServerErr.Error())
@@ -103,7 +103,7 @@ func (k *kernelVethServer) Close(ctx context.Context, conn *networkservice.Conne
 		var kernelServerErr error
 		ovsPortInfo, exists := ifnames.LoadAndDelete(ctx, metadata.IsClient(k))
 		if exists {
-			kernelServerErr = resetVeth(ctx, logger, conn, k.bridgeName, k.parentIfRefCountMap, k.serviceToparentIfMap, ovsPortInfo.IsL2Connect, metadata.IsClient(k))
+			kernelServerErr = resetVeth(ctx, logger, conn, k.bridgeName, k.parentIfRefCountMap, k.trunkParentIfMap, ovsPortInfo.IsL2Connect, metadata.IsClient(k))
 		}
 		if err != nil && kernelServerErr != nil {
 			return nil, errors.Wrap(err, kernelServerErr.Error())
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vhostuser
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	netdevDatapathType = "netdev"
+	// linkName - the end of the veth link attached to the integration bridge
+	linkName = "nsm-vhu-link"
+	// linkPeerName - the end of the veth link attached to the vhost-user bridge
+	linkPeerName  = "nsm-vhu-peer"
+	maxLinkVlanID = 4094
+)
+
+// Bridge is the netdev bridge of the vhost-user ports, the integration bridge stays on the kernel datapath. The
+// bridges are linked by a veth pair which carries the traffic of each vhost-user port in a VLAN of its own, so
+// that the vhost-user ports are cross connected on the integration bridge like the ports of a VLAN trunk.
+type Bridge struct {
+	name           string
+	linkPortNo     int
+	linkPeerPortNo int
+	mutex          sync.Mutex
+	lastVlanID     uint32
+	vlanIDs        map[uint32]bool
+}
+
+// NewBridge creates the netdev bridge of the vhost-user ports and links it to the integration bridge
+func NewBridge(ctx context.Context, bridgeName, integrationBridgeName string) (*Bridge, error) {
+	logger := log.FromContext(ctx).WithField("vhostUserBridge", bridgeName)
+	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-br", bridgeName)
+	if err != nil {
+		logger.Errorf("Failed to add bridge %s, stdout: %q, stderr: %q, error: %v", bridgeName, stdout, stderr, err)
+		return nil, errors.Wrapf(err, "failed to add bridge %s, stdout: %q, stderr: %q", bridgeName, stdout, stderr)
+	}
+	if err = ovsutil.ConfigureDatapathType(ctx, bridgeName, netdevDatapathType); err != nil {
+		return nil, err
+	}
+	// the bridge forwards the traffic between the vhost-user ports and the link only
+	stdout, stderr, err = util.RunOVSOfctl("del-flows", bridgeName)
+	if err != nil {
+		logger.Warnf("Failed to cleanup flows on %s stdout: %q, stderr: %q, error: %v", bridgeName, stdout, stderr, err)
+	}
+	if err = createLink(); err != nil {
+		return nil, err
+	}
+	b := &Bridge{name: bridgeName, vlanIDs: make(map[uint32]bool)}
+	if b.linkPortNo, err = addPort(logger, integrationBridgeName, linkName); err != nil {
+		return nil, err
+	}
+	if b.linkPeerPortNo, err = addPort(logger, bridgeName, linkPeerName); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// connect forwards the traffic of the vhost-user port to the integration bridge in a VLAN of the link and returns
+// the port of the link VLAN on the integration bridge
+func (b *Bridge) connect(logger log.Logger, portName string, portNo int) (*ifnames.OvsPortInfo, error) {
+	vlanID, err := b.allocateVlanID()
+	if err != nil {
+		return nil, err
+	}
+	for _, ofRule := range []string{
+		fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%d,actions=strip_vlan,output:%d", b.linkPeerPortNo, vlanID, portNo),
+		fmt.Sprintf("priority=100,in_port=%d,actions=push_vlan:0x8100,set_field:%d->vlan_vid,output:%d", portNo, vlanID+4096, b.linkPeerPortNo),
+	} {
+		stdout, stderr, addErr := util.RunOVSOfctl("add-flow", "-OOpenflow13", b.name, ofRule)
+		if addErr != nil {
+			logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
+				" stderr: %s, error: %v", b.name, portName, stdout, stderr, addErr)
+			b.disconnect(logger, portName, vlanID)
+			return nil, errors.Wrapf(addErr, "failed to add flow on %s for port %s stdout: %s stderr: %s", b.name, portName, stdout, stderr)
+		}
+	}
+	return &ifnames.OvsPortInfo{PortName: linkName, LinkedPortName: portName, PortNo: b.linkPortNo, VlanID: vlanID}, nil
+}
+
+// disconnect deletes the flows of the vhost-user port and releases its link VLAN
+func (b *Bridge) disconnect(logger log.Logger, portName string, vlanID uint32) {
+	for _, ofMatch := range []string{
+		fmt.Sprintf("in_port=%d,dl_vlan=%d", b.linkPeerPortNo, vlanID),
+		"in_port=" + portName,
+	} {
+		stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", b.name, ofMatch)
+		if err != nil {
+			logger.Errorf("Failed to delete flow on %s for port %s, stdout: %q, stderr: %q, error: %v",
+				b.name, portName, stdout, stderr, err)
+		}
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.vlanIDs, vlanID)
+}
+
+func (b *Bridge) allocateVlanID() (uint32, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for i := 0; i < maxLinkVlanID; i++ {
+		b.lastVlanID = b.lastVlanID%maxLinkVlanID + 1
+		if !b.vlanIDs[b.lastVlanID] {
+			b.vlanIDs[b.lastVlanID] = true
+			return b.lastVlanID, nil
+		}
+	}
+	return 0, errors.Errorf("no free VLAN ID left on the link of %s", b.name)
+}
+
+// createLink creates the veth pair linking the bridges unless it exists, and sets it up
+func createLink() error {
+	if _, err := netlink.LinkByName(linkName); err != nil {
+		veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: linkName}, PeerName: linkPeerName}
+		if err = netlink.LinkAdd(veth); err != nil {
+			return errors.Wrapf(err, "failed to create veth pair %s/%s", linkName, linkPeerName)
+		}
+	}
+	for _, name := range []string{linkName, linkPeerName} {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find link %s", name)
+		}
+		if err = netlink.LinkSetUp(link); err != nil {
+			return errors.Wrapf(err, "failed to set link %s up", name)
+		}
+	}
+	return nil
+}
+
+func addPort(logger log.Logger, bridgeName, portName string) (int, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", bridgeName, portName)
+	if err != nil {
+		logger.Errorf("Failed to add port %s to %s, stdout: %q, stderr: %q, error: %v", portName, bridgeName, stdout, stderr, err)
+		return 0, errors.Wrapf(err, "failed to add port %s to %s, stdout: %q, stderr: %q", portName, bridgeName, stdout, stderr)
+	}
+	return ovsutil.GetInterfaceOfPort(logger, portName)
+}
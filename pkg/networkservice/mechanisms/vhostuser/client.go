@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package vhostuser implements client and server chain elements for the vhost-user mechanism
+// backed by dpdkvhostuserclient ports on an OVS-DPDK (netdev) bridge
+package vhostuser
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/cls"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+type vhostUserClient struct {
+	bridge    *Bridge
+	socketDir string
+}
+
+// NewClient returns a client chain element implementing vhost-user mechanism. The vhost-user port is
+// created on the given netdev bridge and its socket is expected to be served by the endpoint pod under socketDir.
+func NewClient(bridge *Bridge, socketDir string) networkservice.NetworkServiceClient {
+	return &vhostUserClient{bridge: bridge, socketDir: socketDir}
+}
+
+func (c *vhostUserClient) Request(
+	ctx context.Context,
+	request *networkservice.NetworkServiceRequest,
+	opts ...grpc.CallOption,
+) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("vhostUserClient", "Request")
+
+	_, isEstablished := ifnames.Load(ctx, metadata.IsClient(c))
+
+	mechanism := &networkservice.Mechanism{
+		Cls:        cls.LOCAL,
+		Type:       MECHANISM,
+		Parameters: make(map[string]string),
+	}
+	setSocketParameters(ToMechanism(mechanism), request.GetConnection(), c.socketDir, metadata.IsClient(c))
+	request.MechanismPreferences = append(request.MechanismPreferences, mechanism)
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil || isEstablished {
+		return conn, err
+	}
+
+	if err = setupVhostUser(ctx, logger, conn, c.bridge, c.socketDir, metadata.IsClient(c)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			logger.Errorf("failed to close failed connection: %s %s", conn.GetId(), closeErr.Error())
+		}
+	}
+
+	return conn, err
+}
+
+func (c *vhostUserClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	logger := log.FromContext(ctx).WithField("vhostUserClient", "Close")
+	_, err := next.Client(ctx).Close(ctx, conn, opts...)
+
+	ovsPortInfo, _ := ifnames.Load(ctx, metadata.IsClient(c))
+	vhostUserErr := resetVhostUser(logger, conn, c.bridge, ovsPortInfo, metadata.IsClient(c))
+
+	if err != nil && vhostUserErr != nil {
+		return nil, errors.Wrap(err, vhostUserErr.Error())
+	}
+	if vhostUserErr != nil {
+		return nil, vhostUserErr
+	}
+
+	return &empty.Empty{}, err
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vhostuser
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// setSocketParameters fills in the socket parameters of the mechanism, so that the pod knows
+// where it has to serve the vhost-user socket.
+func setSocketParameters(mechanism *Mechanism, conn *networkservice.Connection, socketDir string, isClient bool) {
+	if mechanism.GetSocketPath() != "" {
+		return
+	}
+	filename := getPortName(conn, isClient)
+	mechanism.SetSocketFilename(filename)
+	mechanism.SetSocketPath(filepath.Join(socketDir, filename))
+}
+
+func setupVhostUser(ctx context.Context, logger log.Logger, conn *networkservice.Connection, bridge *Bridge, socketDir string, isClient bool) error {
+	var mechanism *Mechanism
+	if mechanism = ToMechanism(conn.GetMechanism()); mechanism == nil {
+		return nil
+	}
+	if _, ok := ifnames.Load(ctx, isClient); ok {
+		return nil
+	}
+	setSocketParameters(mechanism, conn, socketDir, isClient)
+
+	// ovs acts as vhost-user client, the socket is created by the application running in the pod.
+	portName := getPortName(conn, isClient)
+	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", bridge.name, portName,
+		"--", "set", "interface", portName, "type=dpdkvhostuserclient",
+		"options:vhost-server-path="+mechanism.GetSocketPath())
+	if err != nil {
+		logger.Errorf("Failed to add vhost-user port %s to %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, bridge.name, stdout, stderr, err)
+		return errors.Wrapf(err, "Failed to add vhost-user port %s to %s, stdout: %q, stderr: %q", portName, bridge.name, stdout, stderr)
+	}
+
+	portNo, err := ovsutil.GetInterfaceOfPort(logger, portName)
+	if err != nil {
+		logger.Errorf("Failed to get OVS port number for %s interface,"+
+			" error: %v", portName, err)
+		return err
+	}
+
+	// the port is cross connected on the integration bridge through its VLAN of the bridge link
+	ovsPortInfo, err := bridge.connect(logger, portName, portNo)
+	if err != nil {
+		return err
+	}
+	ifnames.Store(ctx, isClient, ovsPortInfo)
+	return nil
+}
+
+func resetVhostUser(logger log.Logger, conn *networkservice.Connection, bridge *Bridge, ovsPortInfo *ifnames.OvsPortInfo, isClient bool) error {
+	if mechanism := ToMechanism(conn.GetMechanism()); mechanism == nil {
+		return nil
+	}
+	portName := getPortName(conn, isClient)
+	if ovsPortInfo != nil {
+		bridge.disconnect(logger, portName, ovsPortInfo.VlanID)
+	}
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "del-port", bridge.name, portName)
+	if err != nil {
+		logger.Errorf("Failed to delete vhost-user port %s from %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, bridge.name, stdout, stderr, err)
+		return errors.Wrapf(err, "Failed to delete vhost-user port %s from %s, stdout: %q, stderr: %q", portName, bridge.name, stdout, stderr)
+	}
+	return nil
+}
+
+func getPortName(conn *networkservice.Connection, isClient bool) string {
+	prefix := ovsPortSrcPrefix
+	if isClient {
+		prefix = ovsPortDstPrefix
+	}
+	name := fmt.Sprintf("%s-%s", prefix, conn.GetId())
+	if len(name) > kernel.LinuxIfMaxLength {
+		name = name[:kernel.LinuxIfMaxLength]
+	}
+	return name
+}
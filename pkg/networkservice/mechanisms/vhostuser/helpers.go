@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vhostuser
+
+import (
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Mechanism - vhost-user mechanism helper
+type Mechanism struct {
+	*networkservice.Mechanism
+}
+
+// ToMechanism - convert unified mechanism to helper type
+func ToMechanism(m *networkservice.Mechanism) *Mechanism {
+	if m.GetType() == MECHANISM {
+		return &Mechanism{
+			m,
+		}
+	}
+	return nil
+}
+
+// GetParameters returns the map of all parameters to the mechanism
+func (m *Mechanism) GetParameters() map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	if m.Parameters == nil {
+		m.Parameters = map[string]string{}
+	}
+	return m.Parameters
+}
+
+// GetSocketFilename returns the vhost-user socket file name
+func (m *Mechanism) GetSocketFilename() string {
+	return m.GetParameters()[SocketFilename]
+}
+
+// SetSocketFilename sets the vhost-user socket file name
+func (m *Mechanism) SetSocketFilename(filename string) {
+	m.GetParameters()[SocketFilename] = filename
+}
+
+// GetSocketPath returns the vhost-user socket host path
+func (m *Mechanism) GetSocketPath() string {
+	return m.GetParameters()[SocketPath]
+}
+
+// SetSocketPath sets the vhost-user socket host path
+func (m *Mechanism) SetSocketPath(path string) {
+	m.GetParameters()[SocketPath] = path
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vhostuser
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+type vhostUserServer struct {
+	bridge    *Bridge
+	socketDir string
+}
+
+// NewServer - returns a new server chain element for the vhost-user mechanism. The vhost-user port is
+// created on the given netdev bridge and its socket is expected to be served by the client pod under socketDir.
+func NewServer(bridge *Bridge, socketDir string) networkservice.NetworkServiceServer {
+	return &vhostUserServer{bridge: bridge, socketDir: socketDir}
+}
+
+func (v *vhostUserServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("vhostUserServer", "Request")
+
+	_, isEstablished := ifnames.Load(ctx, metadata.IsClient(v))
+
+	if !isEstablished {
+		if err := setupVhostUser(ctx, logger, request.GetConnection(), v.bridge, v.socketDir, metadata.IsClient(v)); err != nil {
+			_ = resetVhostUser(logger, request.GetConnection(), v.bridge, nil, metadata.IsClient(v))
+			return nil, err
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !isEstablished {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if ovsPortInfo, exists := ifnames.LoadAndDelete(closeCtx, metadata.IsClient(v)); exists {
+			if vhostUserErr := resetVhostUser(logger, request.GetConnection(), v.bridge, ovsPortInfo, metadata.IsClient(v)); vhostUserErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", vhostUserErr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	return conn, err
+}
+
+func (v *vhostUserServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	logger := log.FromContext(ctx).WithField("vhostUserServer", "Close")
+	_, err := next.Server(ctx).Close(ctx, conn)
+
+	if mechanism := ToMechanism(conn.GetMechanism()); mechanism != nil {
+		var vhostUserErr error
+		if ovsPortInfo, exists := ifnames.LoadAndDelete(ctx, metadata.IsClient(v)); exists {
+			vhostUserErr = resetVhostUser(logger, conn, v.bridge, ovsPortInfo, metadata.IsClient(v))
+		}
+		if err != nil && vhostUserErr != nil {
+			return nil, errors.Wrap(err, vhostUserErr.Error())
+		}
+		if vhostUserErr != nil {
+			return nil, vhostUserErr
+		}
+	}
+
+	return &empty.Empty{}, err
+}
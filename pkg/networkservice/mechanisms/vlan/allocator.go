@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+	"sync"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/pkg/errors"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// vlanAllocator keeps track of the VLAN IDs used by the connections of every l2 connection point and hands out
+// free ones to the connections the endpoint gives no VLAN ID
+type vlanAllocator struct {
+	mutex sync.Mutex
+	inUse map[string]map[uint32]int
+}
+
+func newVlanAllocator() *vlanAllocator {
+	return &vlanAllocator{inUse: make(map[string]map[uint32]int)}
+}
+
+// allocate returns a free VLAN ID from the allowed ranges of the l2 connection point, 0 when no range is set
+func (a *vlanAllocator) allocate(selector string, l2Point *ovsutil.L2ConnectionPoint) (uint32, error) {
+	if len(l2Point.AllowedVlans) == 0 {
+		return 0, nil
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for _, vlanRange := range l2Point.AllowedVlans {
+		for vlanID := vlanRange.Min; vlanID <= vlanRange.Max; vlanID++ {
+			// VLAN 0 only carries priority, it doesn't separate the connections
+			if vlanID == 0 || a.inUse[selector][vlanID] > 0 || !l2Point.IsVlanAllowed(vlanID) {
+				continue
+			}
+			a.acquireLocked(selector, vlanID)
+			return vlanID, nil
+		}
+	}
+	return 0, errors.Errorf("no free vlan left on %s, allowed vlans: %v", l2Point.Bridge, l2Point.AllowedVlans)
+}
+
+func (a *vlanAllocator) acquire(selector string, vlanID uint32) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.acquireLocked(selector, vlanID)
+}
+
+func (a *vlanAllocator) acquireLocked(selector string, vlanID uint32) {
+	if a.inUse[selector] == nil {
+		a.inUse[selector] = make(map[uint32]int)
+	}
+	a.inUse[selector][vlanID]++
+}
+
+func (a *vlanAllocator) release(selector string, vlanID uint32) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.inUse[selector][vlanID] <= 1 {
+		delete(a.inUse[selector], vlanID)
+		return
+	}
+	a.inUse[selector][vlanID]--
+}
+
+// vlanHold is the VLAN ID used by a connection on an l2 connection point
+type vlanHold struct {
+	selector string
+	vlanID   uint32
+}
+
+type vlanHoldKey struct{}
+
+func storeVlanHold(ctx context.Context, hold *vlanHold) {
+	metadata.Map(ctx, true).Store(vlanHoldKey{}, hold)
+}
+
+func loadVlanHold(ctx context.Context) (value *vlanHold, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(vlanHoldKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*vlanHold)
+	return value, ok
+}
+
+func loadAndDeleteVlanHold(ctx context.Context) (value *vlanHold, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).LoadAndDelete(vlanHoldKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*vlanHold)
+	return value, ok
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+func TestVlanAllocator_Allocate(t *testing.T) {
+	for _, sample := range []struct {
+		name     string
+		l2Point  *ovsutil.L2ConnectionPoint
+		inUse    []uint32
+		expected []uint32
+	}{
+		{
+			name:     "no allowed vlans",
+			l2Point:  &ovsutil.L2ConnectionPoint{Bridge: "br1"},
+			expected: []uint32{0, 0},
+		},
+		{
+			name: "single range",
+			l2Point: &ovsutil.L2ConnectionPoint{Bridge: "br1", AllowedVlans: []ovsutil.VlanRange{
+				{Min: 100, Max: 102},
+			}},
+			expected: []uint32{100, 101, 102},
+		},
+		{
+			name: "vlan 0 is skipped",
+			l2Point: &ovsutil.L2ConnectionPoint{Bridge: "br1", AllowedVlans: []ovsutil.VlanRange{
+				{Min: 0, Max: 1},
+			}},
+			expected: []uint32{1},
+		},
+		{
+			name: "reserved and used vlans are skipped",
+			l2Point: &ovsutil.L2ConnectionPoint{Bridge: "br1", AllowedVlans: []ovsutil.VlanRange{
+				{Min: 10, Max: 12}, {Min: 20, Max: 21},
+			}, ReservedVlans: []uint32{11, 20}},
+			inUse:    []uint32{10},
+			expected: []uint32{12, 21},
+		},
+		{
+			name: "no free vlan left",
+			l2Point: &ovsutil.L2ConnectionPoint{Bridge: "br1", AllowedVlans: []ovsutil.VlanRange{
+				{Min: 10, Max: 10},
+			}},
+			inUse: []uint32{10},
+		},
+	} {
+		t.Run(sample.name, func(t *testing.T) {
+			allocator := newVlanAllocator()
+			for _, vlanID := range sample.inUse {
+				allocator.acquire("selector", vlanID)
+			}
+			for _, expected := range sample.expected {
+				vlanID, err := allocator.allocate("selector", sample.l2Point)
+				require.NoError(t, err)
+				require.Equal(t, expected, vlanID)
+			}
+			if len(sample.l2Point.AllowedVlans) > 0 {
+				_, err := allocator.allocate("selector", sample.l2Point)
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVlanAllocator_Release(t *testing.T) {
+	l2Point := &ovsutil.L2ConnectionPoint{Bridge: "br1", AllowedVlans: []ovsutil.VlanRange{{Min: 100, Max: 101}}}
+	allocator := newVlanAllocator()
+
+	vlanID, err := allocator.allocate("selector", l2Point)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	// the same VLAN ID given by the endpoint to another connection
+	allocator.acquire("selector", 100)
+	allocator.release("selector", 100)
+	vlanID, err = allocator.allocate("selector", l2Point)
+	require.NoError(t, err)
+	require.Equal(t, uint32(101), vlanID)
+
+	allocator.release("selector", 100)
+	vlanID, err = allocator.allocate("selector", l2Point)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+
+	// the VLAN IDs are tracked per l2 connection point
+	vlanID, err = allocator.allocate("other", l2Point)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), vlanID)
+}
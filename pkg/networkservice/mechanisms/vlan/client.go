@@ -24,8 +24,9 @@ package vlan
 
 import (
 	"context"
-	"fmt"
+	"sync"
 
+	"github.com/edwarnicke/genericsync"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -34,6 +35,7 @@ import (
 	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 	"github.com/pkg/errors"
@@ -44,20 +46,37 @@ import (
 	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
 )
 
-const (
-	viaLabel = "via"
-)
-
 type vlanClient struct {
 	bridgeName    string
-	l2Connections map[string]*ovsutil.L2ConnectionPoint
+	l2Connections *genericsync.Map[string, *ovsutil.L2ConnectionPoint]
+	patchPorts    bool
+	allocator     *vlanAllocator
+	// patchFlowsMutex serializes the patch port flow updates, see addPatchFlows
+	patchFlowsMutex sync.Mutex
 }
 
 // NewClient returns a client chain element implementing VLAN breakout for NS client
-func NewClient(bridgeName string, l2Connections map[string]*ovsutil.L2ConnectionPoint) networkservice.NetworkServiceClient {
+func NewClient(bridgeName string, l2Connections map[string]*ovsutil.L2ConnectionPoint, options ...Option) networkservice.NetworkServiceClient {
+	opts := &vlanOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	registry := opts.registry
+	if registry == nil {
+		registry = ovsutil.NewL2ConnectionRegistry(l2Connections)
+	}
+	mtuOpts := append([]mtu.Option{mtu.WithL2ConnectionRegistry(registry)}, opts.mtuOpts...)
+	if opts.chainCtx != nil {
+		mtuOpts = append(mtuOpts, mtu.WithChainContext(opts.chainCtx))
+	}
 	return chain.NewNetworkServiceClient(
-		mtu.NewClient(l2Connections),
-		&vlanClient{bridgeName: bridgeName, l2Connections: l2Connections},
+		mtu.NewClient(nil, mtuOpts...),
+		&vlanClient{
+			bridgeName:    bridgeName,
+			l2Connections: registry,
+			patchPorts:    opts.patchPorts,
+			allocator:     newVlanAllocator(),
+		},
 	)
 }
 
@@ -68,14 +87,25 @@ func (c *vlanClient) Request(ctx context.Context, request *networkservice.Networ
 		Type:       vlanmech.MECHANISM,
 		Parameters: make(map[string]string),
 	}
+	offer, isNewOffer, err := c.offerVlan(ctx, request.GetConnection().GetLabels())
+	if err != nil {
+		return nil, err
+	}
+	if offer.vlanID > 0 {
+		vlanmech.ToMechanism(mechanism).SetVlanID(offer.vlanID)
+	}
 	request.MechanismPreferences = append(request.MechanismPreferences, mechanism)
 
 	postponeCtxFunc := postpone.ContextWithValues(ctx)
 
 	conn, err := next.Client(ctx).Request(ctx, request, opts...)
 	if err != nil {
+		if isNewOffer && offer.vlanID > 0 {
+			c.allocator.release(offer.selector, offer.vlanID)
+		}
 		return nil, err
 	}
+	c.holdVlan(ctx, conn, offer)
 
 	if err := c.addDelVlan(ctx, logger, conn, true); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
@@ -93,6 +123,9 @@ func (c *vlanClient) Close(ctx context.Context, conn *networkservice.Connection,
 	logger := log.FromContext(ctx).WithField("vlanClient", "Close")
 	_, err := next.Client(ctx).Close(ctx, conn, opts...)
 	vlanMechErr := c.addDelVlan(ctx, logger, conn, false)
+	if hold, ok := loadAndDeleteVlanHold(ctx); ok {
+		c.allocator.release(hold.selector, hold.vlanID)
+	}
 	if err != nil && vlanMechErr != nil {
 		return nil, errors.Wrap(err, vlanMechErr.Error())
 	}
@@ -102,46 +135,178 @@ func (c *vlanClient) Close(ctx context.Context, conn *networkservice.Connection,
 	return &empty.Empty{}, err
 }
 
+// offerVlan returns the VLAN ID held by the connection, or a VLAN ID allocated on the l2 connection point for a new
+// connection which is offered to the endpoint
+func (c *vlanClient) offerVlan(ctx context.Context, labels map[string]string) (offer *vlanHold, isNew bool, err error) {
+	if hold, ok := loadVlanHold(ctx); ok {
+		return hold, false, nil
+	}
+	// the mechanism is not selected yet, so a missing connection point is not an error here
+	selector, l2Point, selectErr := ovsutil.SelectL2ConnectionPoint(c.l2Connections, labels)
+	offer = &vlanHold{selector: selector}
+	if selectErr == nil {
+		if offer.vlanID, err = c.allocator.allocate(selector, l2Point); err != nil {
+			return nil, false, err
+		}
+	}
+	return offer, true, nil
+}
+
+// holdVlan makes the connection hold the VLAN ID given by the endpoint, or the offered one if the endpoint
+// gives no VLAN ID
+func (c *vlanClient) holdVlan(ctx context.Context, conn *networkservice.Connection, offer *vlanHold) {
+	mechanism := vlanmech.ToMechanism(conn.GetMechanism())
+	vlanID := mechanism.GetVlanID()
+	if mechanism != nil && vlanID == 0 && offer.vlanID > 0 {
+		mechanism.SetVlanID(offer.vlanID)
+		vlanID = offer.vlanID
+	}
+	if vlanID != offer.vlanID {
+		if offer.vlanID > 0 {
+			c.allocator.release(offer.selector, offer.vlanID)
+		}
+		if vlanID > 0 {
+			c.allocator.acquire(offer.selector, vlanID)
+		}
+	}
+	if vlanID > 0 {
+		storeVlanHold(ctx, &vlanHold{selector: offer.selector, vlanID: vlanID})
+		return
+	}
+	loadAndDeleteVlanHold(ctx)
+}
+
 func (c *vlanClient) addDelVlan(ctx context.Context, logger log.Logger, conn *networkservice.Connection, isAdd bool) error {
 	mechanism := vlanmech.ToMechanism(conn.GetMechanism())
 	if mechanism == nil {
 		return nil
 	}
 	nsClientOvsPortInfo, ok := ifnames.Load(ctx, false)
-	if !ok || (isAdd && nsClientOvsPortInfo.IsCrossConnected) {
-		return nil
-	}
-	viaSelector, ok := conn.GetLabels()[viaLabel]
 	if !ok {
 		return nil
 	}
-	l2Point, ok := c.l2Connections[viaSelector]
-	if !ok {
+	var l2Point *ovsutil.L2ConnectionPoint
+	if isAdd {
+		var err error
+		if l2Point, err = selectL2ConnectionPoint(ctx, c.l2Connections, conn.GetLabels()); err != nil {
+			return errors.Wrap(err, "vlan mechanism is selected but no uplink matches")
+		}
+	} else if l2Point, ok = loadAndDeleteL2ConnectionPoint(ctx); !ok {
 		return nil
 	}
+	sVlanID := ovsutil.GetServiceVlanID(mechanism.GetParameters())
+	// the refresh may change the VLAN IDs too, so they are admitted on every add
 	if isAdd {
-		// delete the ns client port from br-nsm bridge and add it into l2 connect bridge with vlan tag.
-		stdout, stderr, err := util.RunOVSVsctl("del-port", c.bridgeName, nsClientOvsPortInfo.PortName)
-		if err != nil {
-			logger.Errorf("Failed to delete port %s from %s, stdout: %q, stderr: %q,"+
-				" error: %v", nsClientOvsPortInfo.PortName, c.bridgeName, stdout, stderr, err)
-			return errors.Wrapf(err, "Failed to delete port %s from %s, stdout: %q, stderr: %q", nsClientOvsPortInfo.PortName, c.bridgeName, stdout, stderr)
+		if err := checkVlanAdmission(l2Point, mechanism, sVlanID); err != nil {
+			return err
 		}
-		stdout, stderr, err = util.RunOVSVsctl("--", "--may-exist", "add-port", l2Point.Bridge,
-			nsClientOvsPortInfo.PortName, fmt.Sprintf("tag=%d", mechanism.GetVlanID()))
-		if err != nil {
-			logger.Errorf("Failed to add port %s to %s, stdout: %q, stderr: %q,"+
-				" error: %v", nsClientOvsPortInfo.PortName, l2Point.Bridge, stdout, stderr, err)
-			return errors.Wrapf(err, "Failed to add port %s to %s, stdout: %q, stderr: %q", nsClientOvsPortInfo.PortName, l2Point.Bridge, stdout, stderr)
+	}
+	if isAdd && sVlanID > 0 {
+		if err := ovsutil.EnableDoubleTagging(); err != nil {
+			return err
 		}
-		nsClientOvsPortInfo.IsL2Connect = true
-		nsClientOvsPortInfo.IsCrossConnected = true
-	} else {
-		stdout, stderr, err := util.RunOVSVsctl("del-port", l2Point.Bridge, nsClientOvsPortInfo.PortName)
-		if err != nil {
-			logger.Errorf("Failed to delete port %s from %s, stdout: %q, stderr: %q,"+
-				" error: %v", nsClientOvsPortInfo.PortName, l2Point.Bridge, stdout, stderr, err)
+	}
+	if c.patchPorts {
+		return c.addDelPatch(ctx, logger, l2Point, nsClientOvsPortInfo, mechanism, isAdd)
+	}
+	if !isAdd {
+		c.delVlan(ctx, logger, l2Point, nsClientOvsPortInfo.PortName, sVlanID, mechanism.GetVlanID())
+		return nil
+	}
+	config, err := getPortVlanConfig(mechanism)
+	if err != nil {
+		return err
+	}
+	if sVlanID > 0 && config.isTrunk() {
+		return errors.Errorf("vlan trunk is not supported together with service vlan %d", sVlanID)
+	}
+	if nsClientOvsPortInfo.IsCrossConnected {
+		// the trunk list is updated in place on refresh
+		if sVlanID > 0 {
+			return nil
 		}
+		return updatePortVlanConfig(ctx, logger, nsClientOvsPortInfo.PortName, config)
 	}
+	if err = c.addVlan(logger, l2Point, nsClientOvsPortInfo.PortName, sVlanID, mechanism.GetVlanID(), config); err != nil {
+		return err
+	}
+	storePortVlanConfig(ctx, config)
+	nsClientOvsPortInfo.IsL2Connect = true
+	nsClientOvsPortInfo.IsCrossConnected = true
 	return nil
 }
+
+func (c *vlanClient) addVlan(logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, portName string, sVlanID, vlanID uint32,
+	config *portVlanConfig) error {
+	// delete the ns client port from br-nsm bridge and add it into l2 connect bridge with vlan tag or trunks.
+	stdout, stderr, err := util.RunOVSVsctl("del-port", c.bridgeName, portName)
+	if err != nil {
+		logger.Errorf("Failed to delete port %s from %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, c.bridgeName, stdout, stderr, err)
+		return errors.Wrapf(err, "Failed to delete port %s from %s, stdout: %q, stderr: %q", portName, c.bridgeName, stdout, stderr)
+	}
+	// with 802.1ad service VLAN, both the tags are handled by the flows instead of the port tag.
+	portArgs := []string{"--", "--may-exist", "add-port", l2Point.Bridge, portName}
+	if sVlanID == 0 {
+		portArgs = append(portArgs, config.columns()...)
+	}
+	stdout, stderr, err = util.RunOVSVsctl(portArgs...)
+	if err != nil {
+		logger.Errorf("Failed to add port %s to %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, l2Point.Bridge, stdout, stderr, err)
+		return errors.Wrapf(err, "Failed to add port %s to %s, stdout: %q, stderr: %q", portName, l2Point.Bridge, stdout, stderr)
+	}
+	if sVlanID > 0 {
+		return addQinQFlows(logger, l2Point, portName, sVlanID, vlanID)
+	}
+	return nil
+}
+
+func (c *vlanClient) delVlan(ctx context.Context, logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, portName string, sVlanID, vlanID uint32) {
+	deletePortVlanConfig(ctx)
+	if sVlanID > 0 {
+		if err := deleteQinQFlows(logger, l2Point, portName, sVlanID, vlanID); err != nil {
+			logger.Errorf("Failed to delete QinQ flows of port %s from %s, error: %v", portName, l2Point.Bridge, err)
+		}
+	}
+	stdout, stderr, err := util.RunOVSVsctl("del-port", l2Point.Bridge, portName)
+	if err != nil {
+		logger.Errorf("Failed to delete port %s from %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, l2Point.Bridge, stdout, stderr, err)
+	}
+}
+
+// selectL2ConnectionPoint returns the l2 connection point selected when the connection was added, so that a
+// refresh and the close use the same one even if the labels or the connection points change meanwhile
+func selectL2ConnectionPoint(ctx context.Context, l2Connections *genericsync.Map[string, *ovsutil.L2ConnectionPoint],
+	labels map[string]string) (*ovsutil.L2ConnectionPoint, error) {
+	if l2Point, ok := loadL2ConnectionPoint(ctx); ok {
+		return l2Point, nil
+	}
+	_, l2Point, err := ovsutil.SelectL2ConnectionPoint(l2Connections, labels)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Map(ctx, true).Store(l2ConnectionPointKey{}, l2Point)
+	return l2Point, nil
+}
+
+type l2ConnectionPointKey struct{}
+
+func loadL2ConnectionPoint(ctx context.Context) (value *ovsutil.L2ConnectionPoint, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(l2ConnectionPointKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*ovsutil.L2ConnectionPoint)
+	return value, ok
+}
+
+func loadAndDeleteL2ConnectionPoint(ctx context.Context) (value *ovsutil.L2ConnectionPoint, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).LoadAndDelete(l2ConnectionPointKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*ovsutil.L2ConnectionPoint)
+	return value, ok
+}
@@ -21,6 +21,7 @@ package mtu
 
 import (
 	"context"
+	"sync"
 
 	"github.com/edwarnicke/genericsync"
 	"github.com/pkg/errors"
@@ -36,21 +37,34 @@ import (
 	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
 )
 
-const (
-	viaLabel = "via"
-)
-
 type mtuClient struct {
-	l2Connections map[string]*ovsutil.L2ConnectionPoint
+	l2Connections *genericsync.Map[string, *ovsutil.L2ConnectionPoint]
 	mtus          *genericsync.Map[string, uint32]
+	// cacheMutex serializes filling the cache with the link updates, an update received while the MTU is
+	// looked up would be missed otherwise
+	cacheMutex  sync.Mutex
+	cacheMTUs   bool
+	readvertise bool
 }
 
 // NewClient - returns client chain element to manage vlan MTU
-func NewClient(l2Connections map[string]*ovsutil.L2ConnectionPoint) networkservice.NetworkServiceClient {
-	return &mtuClient{
-		l2Connections: l2Connections,
+func NewClient(l2Connections map[string]*ovsutil.L2ConnectionPoint, options ...Option) networkservice.NetworkServiceClient {
+	opts := &mtuOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	m := &mtuClient{
+		l2Connections: opts.registry,
 		mtus:          &genericsync.Map[string, uint32]{},
+		readvertise:   opts.readvertise,
 	}
+	if m.l2Connections == nil {
+		m.l2Connections = ovsutil.NewL2ConnectionRegistry(l2Connections)
+	}
+	if opts.chainCtx != nil {
+		m.cacheMTUs = m.watchLinks(opts.chainCtx)
+	}
+	return m
 }
 
 func (m *mtuClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
@@ -63,40 +77,68 @@ func (m *mtuClient) Request(ctx context.Context, request *networkservice.Network
 	}
 
 	if mechanism := vlan.ToMechanism(conn.GetMechanism()); mechanism != nil {
-		viaSelector, ok := conn.GetLabels()[viaLabel]
-		if !ok {
+		_, l2Point, mtuErr := ovsutil.SelectL2ConnectionPoint(m.l2Connections, conn.GetLabels())
+		if mtuErr == nil && l2Point.Interface == "" && l2Point.MTU == 0 {
 			return conn, nil
 		}
-		l2Point, ok := m.l2Connections[viaSelector]
-		if !ok {
-			return conn, nil
+		var localMTU uint32
+		if mtuErr == nil {
+			localMTU, mtuErr = m.getLocalMTU(l2Point, logger)
 		}
-		if l2Point.Interface == "" {
-			return conn, nil
-		}
-		localMTU, loaded := m.mtus.Load(l2Point.Interface)
-		if !loaded {
-			localMTU, err = getMTU(l2Point, logger)
-			if err != nil {
-				closeCtx, cancelClose := postponeCtxFunc()
-				defer cancelClose()
-				if _, closeErr := m.Close(closeCtx, conn, opts...); closeErr != nil {
-					err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
-				}
-				return nil, err
+		if mtuErr != nil {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+			if _, closeErr := m.Close(closeCtx, conn, opts...); closeErr != nil {
+				mtuErr = errors.Wrapf(mtuErr, "connection closed with error: %s", closeErr.Error())
 			}
-			m.mtus.Store(l2Point.Interface, localMTU)
+			return nil, mtuErr
+		}
+		advertisedMTU, established := loadMTU(ctx)
+		if established && !m.readvertise {
+			// established connections keep the advertised MTU
+			localMTU = advertisedMTU
+		}
+		if conn.GetContext() == nil {
+			conn.Context = &networkservice.ConnectionContext{}
+		}
+		if established && m.readvertise && conn.GetContext().GetMTU() == advertisedMTU {
+			// the MTU is taken over even if it is higher than the one advertised before
+			conn.GetContext().MTU = localMTU
 		}
 		if localMTU > 0 && (conn.GetContext().GetMTU() > localMTU || conn.GetContext().GetMTU() == 0) {
-			if conn.GetContext() == nil {
-				conn.Context = &networkservice.ConnectionContext{}
-			}
 			conn.GetContext().MTU = localMTU
 		}
+		storeMTU(ctx, conn.GetContext().GetMTU())
 	}
 	return conn, nil
 }
 
 func (m *mtuClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	deleteMTU(ctx)
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
+
+// getLocalMTU returns the MTU override of the l2 connection point, otherwise the MTU of its uplink from the
+// cache if link updates are watched
+func (m *mtuClient) getLocalMTU(l2Point *ovsutil.L2ConnectionPoint, logger log.Logger) (uint32, error) {
+	if l2Point.MTU > 0 {
+		return l2Point.MTU, nil
+	}
+	if localMTU, loaded := m.mtus.Load(l2Point.Interface); loaded {
+		return localMTU, nil
+	}
+	if !m.cacheMTUs {
+		return getMTU(l2Point, logger)
+	}
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	if localMTU, loaded := m.mtus.Load(l2Point.Interface); loaded {
+		return localMTU, nil
+	}
+	localMTU, err := getMTU(l2Point, logger)
+	if err != nil {
+		return 0, err
+	}
+	m.mtus.Store(l2Point.Interface, localMTU)
+	return localMTU, nil
+}
@@ -20,6 +20,8 @@
 package mtu
 
 import (
+	"context"
+	"syscall"
 	"time"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
@@ -30,10 +32,28 @@ import (
 )
 
 func getMTU(l2CP *ovsutil.L2ConnectionPoint, logger log.Logger) (uint32, error) {
+	if l2CP.Bond == nil {
+		return getLinkMTU(l2CP.Interface, logger)
+	}
+	// the bond has no kernel link, its MTU is the lowest of its members
+	var bondMTU uint32
+	for _, member := range l2CP.Bond.Members {
+		mtu, err := getLinkMTU(member, logger)
+		if err != nil {
+			return 0, err
+		}
+		if mtu > 0 && (bondMTU == 0 || mtu < bondMTU) {
+			bondMTU = mtu
+		}
+	}
+	return bondMTU, nil
+}
+
+func getLinkMTU(linkName string, logger log.Logger) (uint32, error) {
 	now := time.Now()
-	link, err := netlink.LinkByName(l2CP.Interface)
+	link, err := netlink.LinkByName(linkName)
 	if err != nil {
-		return 0, nil
+		return 0, errors.Wrapf(err, "failed to find link %s", linkName)
 	}
 	mtu := link.Attrs().MTU
 	logger.WithField("link.Name", link.Attrs().Name).
@@ -46,3 +66,67 @@ func getMTU(l2CP *ovsutil.L2ConnectionPoint, logger log.Logger) (uint32, error)
 
 	return 0, errors.New("invalid MTU value")
 }
+
+// watchLinks refreshes the cached MTUs of the l2 connection points on link updates of their interfaces,
+// the MTU of a deleted interface is dropped from the cache. It returns false when link updates can't be
+// subscribed, the MTUs must not be cached then.
+func (m *mtuClient) watchLinks(chainCtx context.Context) bool {
+	logger := log.FromContext(chainCtx).WithField("mtuClient", "watchLinks")
+	linkUpdateCh := make(chan netlink.LinkUpdate)
+	err := netlink.LinkSubscribeWithOptions(linkUpdateCh, chainCtx.Done(), netlink.LinkSubscribeOptions{
+		ErrorCallback: func(err error) {
+			logger.Errorf("link subscription error: %v", err)
+		},
+	})
+	if err != nil {
+		logger.Errorf("failed to subscribe link updates, MTU is looked up on every request: %v", err)
+		return false
+	}
+	go func() {
+		for linkUpdate := range linkUpdateCh {
+			m.linkUpdated(logger, linkUpdate.Attrs().Name, linkUpdate.Header.Type == syscall.RTM_DELLINK)
+		}
+	}()
+	return true
+}
+
+func (m *mtuClient) linkUpdated(logger log.Logger, linkName string, deleted bool) {
+	m.cacheMutex.Lock()
+	defer m.cacheMutex.Unlock()
+	m.l2Connections.Range(func(_ string, l2Point *ovsutil.L2ConnectionPoint) bool {
+		if !usesLink(l2Point, linkName) {
+			return true
+		}
+		cachedMTU, cached := m.mtus.Load(l2Point.Interface)
+		if !cached {
+			return true
+		}
+		if deleted {
+			m.mtus.Delete(l2Point.Interface)
+			return true
+		}
+		mtu, err := getMTU(l2Point, logger)
+		if err != nil {
+			logger.Warnf("failed to refresh MTU of %s: %v", l2Point.Interface, err)
+			m.mtus.Delete(l2Point.Interface)
+			return true
+		}
+		if mtu != cachedMTU {
+			logger.Infof("MTU of %s changed from %d to %d", l2Point.Interface, cachedMTU, mtu)
+			m.mtus.Store(l2Point.Interface, mtu)
+		}
+		return true
+	})
+}
+
+func usesLink(l2Point *ovsutil.L2ConnectionPoint, linkName string) bool {
+	if l2Point.Bond == nil {
+		return l2Point.Interface == linkName
+	}
+	for _, member := range l2Point.Bond.Members {
+		if member == linkName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package mtu
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// storeMTU stores the MTU advertised on the connection
+func storeMTU(ctx context.Context, mtu uint32) {
+	metadata.Map(ctx, true).Store(key{}, mtu)
+}
+
+// loadMTU retrieves the MTU advertised on the connection
+func loadMTU(ctx context.Context) (value uint32, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(uint32)
+	return value, ok
+}
+
+// deleteMTU deletes the MTU advertised on the connection
+func deleteMTU(ctx context.Context) {
+	metadata.Map(ctx, true).Delete(key{})
+}
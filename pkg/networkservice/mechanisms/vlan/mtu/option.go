@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package mtu
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// Option is an option pattern for mtu client
+type Option func(o *mtuOptions)
+
+// WithReadvertise makes the established connections take over the current uplink MTU at their next
+// refresh, by default they keep the MTU advertised when they were established
+func WithReadvertise() Option {
+	return func(o *mtuOptions) {
+		o.readvertise = true
+	}
+}
+
+// WithChainContext caches the uplink MTUs and keeps them up to date from link updates until chainCtx is done,
+// by default the MTU of the uplink is read on each Request
+func WithChainContext(chainCtx context.Context) Option {
+	return func(o *mtuOptions) {
+		o.chainCtx = chainCtx
+	}
+}
+
+// WithL2ConnectionRegistry sets the registry of the l2 connection points kept up to date at runtime, e.g. by
+// l2config.Watch, it is used instead of the l2 connection points given to NewClient
+func WithL2ConnectionRegistry(registry *genericsync.Map[string, *ovsutil.L2ConnectionPoint]) Option {
+	return func(o *mtuOptions) {
+		o.registry = registry
+	}
+}
+
+type mtuOptions struct {
+	readvertise bool
+	chainCtx    context.Context
+	registry    *genericsync.Map[string, *ovsutil.L2ConnectionPoint]
+}
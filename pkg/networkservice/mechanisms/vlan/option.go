@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/mechanisms/vlan/mtu"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// Option is an option pattern for vlan client
+type Option func(o *vlanOptions)
+
+// WithPatchPorts makes the VLAN breakout with tagging flows towards the patch port of the l2 bridge instead of
+// moving the ns client port to the l2 bridge. The patch ports must be created by utils.ConfigurePatchPorts.
+func WithPatchPorts() Option {
+	return func(o *vlanOptions) {
+		o.patchPorts = true
+	}
+}
+
+// WithMTUOptions adds options of the vlan MTU client
+func WithMTUOptions(opts ...mtu.Option) Option {
+	return func(o *vlanOptions) {
+		o.mtuOpts = append(o.mtuOpts, opts...)
+	}
+}
+
+// WithChainContext sets the context of the chain, the vlan MTU client watches the uplink MTU changes until it
+// is done, see mtu.WithChainContext
+func WithChainContext(chainCtx context.Context) Option {
+	return func(o *vlanOptions) {
+		o.chainCtx = chainCtx
+	}
+}
+
+// WithL2ConnectionRegistry sets the registry of the l2 connection points kept up to date at runtime, e.g. by
+// l2config.Watch, it is used instead of the l2 connection points given to NewClient
+func WithL2ConnectionRegistry(registry *genericsync.Map[string, *ovsutil.L2ConnectionPoint]) Option {
+	return func(o *vlanOptions) {
+		o.registry = registry
+	}
+}
+
+type vlanOptions struct {
+	patchPorts bool
+	mtuOpts    []mtu.Option
+	chainCtx   context.Context
+	registry   *genericsync.Map[string, *ovsutil.L2ConnectionPoint]
+}
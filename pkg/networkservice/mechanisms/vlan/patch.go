@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// addDelPatch is the patch port variant of addDelVlan, the ns client port is kept on the integration bridge
+func (c *vlanClient) addDelPatch(ctx context.Context, logger log.Logger, l2Point *ovsutil.L2ConnectionPoint,
+	nsClientOvsPortInfo *ifnames.OvsPortInfo, mechanism *vlanmech.Mechanism, isAdd bool) error {
+	sVlanID := ovsutil.GetServiceVlanID(mechanism.GetParameters())
+	// the flows are only deleted when they were installed, as the patch port flows of another
+	// connection may match the same VLAN
+	applied, ok := loadPortVlanConfig(ctx)
+	if !isAdd {
+		if !ok {
+			return nil
+		}
+		deletePortVlanConfig(ctx)
+		return c.deletePatchFlows(logger, l2Point, nsClientOvsPortInfo, sVlanID, mechanism.GetVlanID(), applied)
+	}
+	config, err := getPortVlanConfig(mechanism)
+	if err != nil {
+		return err
+	}
+	if sVlanID > 0 && config.isTrunk() {
+		return errors.Errorf("vlan trunk is not supported together with service vlan %d", sVlanID)
+	}
+	if ok {
+		// the trunk list is updated in place on refresh
+		if sVlanID > 0 || *applied == *config {
+			return nil
+		}
+		deletePortVlanConfig(ctx)
+		if err = c.deletePatchFlows(logger, l2Point, nsClientOvsPortInfo, sVlanID, mechanism.GetVlanID(), applied); err != nil {
+			return err
+		}
+	}
+	if err = c.addPatchFlows(ctx, logger, l2Point, nsClientOvsPortInfo, sVlanID, mechanism.GetVlanID(), config); err != nil {
+		return err
+	}
+	nsClientOvsPortInfo.IsCrossConnected = true
+	return nil
+}
+
+// addPatchFlows breaks out the ns client port to the l2 bridge with tagging flows on the integration bridge
+// towards its patch port.
+func (c *vlanClient) addPatchFlows(ctx context.Context, logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, nsClientOvsPortInfo *ifnames.OvsPortInfo,
+	sVlanID, vlanID uint32, config *portVlanConfig) error {
+	if nsClientOvsPortInfo.VlanID > 0 {
+		return errors.Errorf("patch port breakout is not supported for vlan trunk port %s", nsClientOvsPortInfo.PortName)
+	}
+	patchPortNo, err := ovsutil.GetInterfaceOfPort(logger, ovsutil.GetPatchPortName(c.bridgeName, l2Point.Bridge))
+	if err != nil {
+		return err
+	}
+	portNo := nsClientOvsPortInfo.PortNo
+	// the VLANs are checked free and taken by the flows atomically
+	c.patchFlowsMutex.Lock()
+	defer c.patchFlowsMutex.Unlock()
+	var ofRules []string
+	if sVlanID > 0 {
+		ofRules = append(ovsutil.QinQPopFlows(patchPortNo, sVlanID, vlanID, fmt.Sprintf("output:%d", portNo)),
+			fmt.Sprintf("priority=100,in_port=%d,actions=%s,output:%d", portNo, ovsutil.QinQPushActions(sVlanID, vlanID), patchPortNo))
+	} else {
+		for _, patchVlanID := range config.vlanIDs() {
+			if err = c.checkPatchVlanFree(patchPortNo, patchVlanID); err != nil {
+				return err
+			}
+		}
+		ofRules = config.patchFlows(portNo, patchPortNo)
+	}
+	storePortVlanConfig(ctx, config)
+	for _, ofRule := range ofRules {
+		stdout, stderr, addErr := util.RunOVSOfctl("add-flow", "-OOpenflow13", c.bridgeName, ofRule)
+		if addErr != nil {
+			logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
+				" stderr: %s, error: %v", c.bridgeName, nsClientOvsPortInfo.PortName, stdout, stderr, addErr)
+			return errors.Wrapf(addErr, "failed to add flow on %s for port %s stdout: %s stderr: %s", c.bridgeName,
+				nsClientOvsPortInfo.PortName, stdout, stderr)
+		}
+	}
+	return nil
+}
+
+// deletePatchFlows deletes the flows installed from addPatchFlows
+func (c *vlanClient) deletePatchFlows(logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, nsClientOvsPortInfo *ifnames.OvsPortInfo,
+	sVlanID, vlanID uint32, config *portVlanConfig) error {
+	patchPortNo, err := ovsutil.GetInterfaceOfPort(logger, ovsutil.GetPatchPortName(c.bridgeName, l2Point.Bridge))
+	if err != nil {
+		return err
+	}
+	ofMatches := []string{fmt.Sprintf("in_port=%d", nsClientOvsPortInfo.PortNo)}
+	if sVlanID > 0 {
+		if err = ovsutil.DeleteQinQPopFlows(c.bridgeName, patchPortNo, sVlanID, vlanID); err != nil {
+			return err
+		}
+	} else {
+		for _, patchVlanID := range config.vlanIDs() {
+			ofMatches = append(ofMatches, fmt.Sprintf("in_port=%d,dl_vlan=%d", patchPortNo, patchVlanID))
+		}
+	}
+	for _, ofMatch := range ofMatches {
+		stdout, stderr, delErr := util.RunOVSOfctl("del-flows", "-OOpenflow13", c.bridgeName, ofMatch)
+		if delErr != nil {
+			return errors.Wrapf(delErr, "failed to delete flow on %s for port %s, stdout: %q, stderr: %q", c.bridgeName,
+				nsClientOvsPortInfo.PortName, stdout, stderr)
+		}
+	}
+	return nil
+}
+
+// checkPatchVlanFree returns error if the VLAN is already broken out through the patch port, as the packets
+// received from the l2 bridge can be delivered to a single ns client port only
+func (c *vlanClient) checkPatchVlanFree(patchPortNo int, vlanID uint32) error {
+	ofMatch := fmt.Sprintf("in_port=%d,dl_vlan=%d", patchPortNo, vlanID)
+	stdout, stderr, err := util.RunOVSOfctl("dump-flows", "-OOpenflow13", "--no-stats", c.bridgeName, ofMatch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dump flows on %s, stdout: %q, stderr: %q", c.bridgeName, stdout, stderr)
+	}
+	if strings.Contains(stdout, "actions=") {
+		return errors.Errorf("vlan %d is already broken out through patch port %d", vlanID, patchPortNo)
+	}
+	return nil
+}
+
+// vlanIDs returns the VLAN IDs carried towards the patch port
+func (p *portVlanConfig) vlanIDs() []uint32 {
+	if !p.isTrunk() {
+		return []uint32{p.tag}
+	}
+	var vlanIDs []uint32
+	for _, trunk := range strings.Split(p.trunks, ",") {
+		vlanID, _ := strconv.ParseUint(trunk, 10, 12)
+		vlanIDs = append(vlanIDs, uint32(vlanID))
+	}
+	if p.native {
+		vlanIDs = append(vlanIDs, p.tag)
+	}
+	return vlanIDs
+}
+
+// patchFlows returns the flows equivalent of the port vlan config between the ns client port and the patch port
+func (p *portVlanConfig) patchFlows(portNo, patchPortNo int) []string {
+	var ofRules []string
+	if !p.isTrunk() || p.native {
+		untaggedMatch := ""
+		if p.isTrunk() {
+			untaggedMatch = ",vlan_tci=0x0000/0x1000"
+		}
+		ofRules = append(ofRules,
+			fmt.Sprintf("priority=100,in_port=%d%s,actions=push_vlan:0x8100,set_field:%d->vlan_vid,output:%d",
+				portNo, untaggedMatch, p.tag+4096, patchPortNo),
+			fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%d,actions=pop_vlan,output:%d", patchPortNo, p.tag, portNo))
+	}
+	if !p.isTrunk() {
+		return ofRules
+	}
+	for _, trunk := range strings.Split(p.trunks, ",") {
+		ofRules = append(ofRules,
+			fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%s,actions=output:%d", portNo, trunk, patchPortNo),
+			fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%s,actions=output:%d", patchPortNo, trunk, portNo))
+	}
+	return ofRules
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"fmt"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// addQinQFlows programs the l2 bridge to push 802.1Q customer VLAN and 802.1ad service VLAN on the packets
+// of the ns client port towards the uplink, and to pop them on the way back.
+func addQinQFlows(logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, portName string, sVlanID, cVlanID uint32) error {
+	uplinkPortNo, portNo, err := getQinQPortNumbers(logger, l2Point, portName)
+	if err != nil {
+		return err
+	}
+	ofRules := append(ovsutil.QinQPopFlows(uplinkPortNo, sVlanID, cVlanID, fmt.Sprintf("output:%d", portNo)),
+		fmt.Sprintf("priority=100,in_port=%d,actions=%s,output:%d", portNo, ovsutil.QinQPushActions(sVlanID, cVlanID), uplinkPortNo))
+	for _, ofRule := range ofRules {
+		stdout, stderr, addErr := util.RunOVSOfctl("add-flow", "-OOpenflow13", l2Point.Bridge, ofRule)
+		if addErr != nil {
+			logger.Errorf("Failed to add flow on %s for port %s stdout: %s"+
+				" stderr: %s, error: %v", l2Point.Bridge, portName, stdout, stderr, addErr)
+			return errors.Wrapf(addErr, "failed to add flow on %s for port %s stdout: %s stderr: %s", l2Point.Bridge, portName, stdout, stderr)
+		}
+	}
+	return nil
+}
+
+func deleteQinQFlows(logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, portName string, sVlanID, cVlanID uint32) error {
+	uplinkPortNo, portNo, err := getQinQPortNumbers(logger, l2Point, portName)
+	if err != nil {
+		return err
+	}
+	if err = ovsutil.DeleteQinQPopFlows(l2Point.Bridge, uplinkPortNo, sVlanID, cVlanID); err != nil {
+		return err
+	}
+	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", l2Point.Bridge, fmt.Sprintf("in_port=%d", portNo))
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete flow on %s for port %s, stdout: %q, stderr: %q", l2Point.Bridge, portName, stdout, stderr)
+	}
+	return nil
+}
+
+func getQinQPortNumbers(logger log.Logger, l2Point *ovsutil.L2ConnectionPoint, portName string) (uplinkPortNo, portNo int, err error) {
+	if l2Point.Interface == "" {
+		return 0, 0, errors.Errorf("QinQ breakout on %s requires an uplink interface", l2Point.Bridge)
+	}
+	// bond members have their own openflow ports, a single in_port can't match the bond uplink
+	if l2Point.Bond != nil {
+		return 0, 0, errors.Errorf("QinQ breakout on %s is not supported over bond %s", l2Point.Bridge, l2Point.Interface)
+	}
+	if uplinkPortNo, err = ovsutil.GetInterfaceOfPort(logger, l2Point.Interface); err != nil {
+		return 0, 0, err
+	}
+	if portNo, err = ovsutil.GetInterfaceOfPort(logger, portName); err != nil {
+		return 0, 0, err
+	}
+	return uplinkPortNo, portNo, nil
+}
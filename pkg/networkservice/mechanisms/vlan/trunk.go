@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	vlanmech "github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vlan"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	// TrunksKey - mechanism parameter key carrying the comma separated VLAN IDs trunked on the ns client port
+	TrunksKey = "trunks"
+	// NativeVlanIDKey - mechanism parameter key carrying the VLAN ID of the untagged packets of a trunk port
+	NativeVlanIDKey = "native-vlan-id"
+)
+
+// portVlanConfig is the vlan configuration of the ns client port on the l2 bridge, either an access
+// port tagged with the mechanism VLAN ID or a trunk port with an optional native VLAN.
+type portVlanConfig struct {
+	tag    uint32
+	trunks string
+	native bool
+}
+
+func getPortVlanConfig(mechanism *vlanmech.Mechanism) (*portVlanConfig, error) {
+	parameters := mechanism.GetParameters()
+	trunks, ok := parameters[TrunksKey]
+	if !ok {
+		return &portVlanConfig{tag: mechanism.GetVlanID()}, nil
+	}
+	var vlanIDs []string
+	for _, trunk := range strings.Split(trunks, ",") {
+		vlanID, err := parseVlanID(trunk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s parameter %q", TrunksKey, trunks)
+		}
+		vlanIDs = append(vlanIDs, strconv.FormatUint(uint64(vlanID), 10))
+	}
+	config := &portVlanConfig{trunks: strings.Join(vlanIDs, ",")}
+	if nativeVlanID, ok := parameters[NativeVlanIDKey]; ok {
+		vlanID, err := parseVlanID(nativeVlanID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s parameter %q", NativeVlanIDKey, nativeVlanID)
+		}
+		config.tag = vlanID
+		config.native = true
+	}
+	return config, nil
+}
+
+// checkVlanAdmission returns error if the VLAN IDs carried on the uplink are not allowed on the l2 connection point
+func checkVlanAdmission(l2Point *ovsutil.L2ConnectionPoint, mechanism *vlanmech.Mechanism, sVlanID uint32) error {
+	vlanIDs := []uint32{sVlanID}
+	if sVlanID == 0 {
+		config, err := getPortVlanConfig(mechanism)
+		if err != nil {
+			return err
+		}
+		vlanIDs = config.vlanIDs()
+	}
+	for _, vlanID := range vlanIDs {
+		if !l2Point.IsVlanAllowed(vlanID) {
+			return errors.Errorf("vlan %d is not allowed on %s, allowed vlans: %v, reserved vlans: %v", vlanID, l2Point.Bridge,
+				l2Point.AllowedVlans, l2Point.ReservedVlans)
+		}
+	}
+	return nil
+}
+
+func parseVlanID(value string) (uint32, error) {
+	// vlan ID range is 0 to 4,095 stored in 12 bit
+	vlanID, err := strconv.ParseUint(strings.TrimSpace(value), 10, 12)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(vlanID), nil
+}
+
+func (p *portVlanConfig) isTrunk() bool {
+	return p.trunks != ""
+}
+
+// columns returns the vlan related port columns for ovs-vsctl, the unused ones are cleared so that
+// the port can be switched between access and trunk mode.
+func (p *portVlanConfig) columns() []string {
+	switch {
+	case !p.isTrunk():
+		return []string{fmt.Sprintf("tag=%d", p.tag), "trunks=[]", "vlan_mode=[]"}
+	case p.native:
+		return []string{fmt.Sprintf("tag=%d", p.tag), fmt.Sprintf("trunks=%s", p.trunks), "vlan_mode=native-untagged"}
+	default:
+		return []string{"tag=[]", fmt.Sprintf("trunks=%s", p.trunks), "vlan_mode=trunk"}
+	}
+}
+
+// updatePortVlanConfig reconfigures the vlan columns of an already connected port in place
+func updatePortVlanConfig(ctx context.Context, logger log.Logger, portName string, config *portVlanConfig) error {
+	applied, ok := loadPortVlanConfig(ctx)
+	if ok && *applied == *config {
+		return nil
+	}
+	stdout, stderr, err := util.RunOVSVsctl(append([]string{"set", "port", portName}, config.columns()...)...)
+	if err != nil {
+		logger.Errorf("Failed to set vlan config of port %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, stdout, stderr, err)
+		return errors.Wrapf(err, "Failed to set vlan config of port %s, stdout: %q, stderr: %q", portName, stdout, stderr)
+	}
+	storePortVlanConfig(ctx, config)
+	return nil
+}
+
+type portVlanConfigKey struct{}
+
+func storePortVlanConfig(ctx context.Context, config *portVlanConfig) {
+	metadata.Map(ctx, true).Store(portVlanConfigKey{}, config)
+}
+
+func loadPortVlanConfig(ctx context.Context) (value *portVlanConfig, ok bool) {
+	rawValue, ok := metadata.Map(ctx, true).Load(portVlanConfigKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*portVlanConfig)
+	return value, ok
+}
+
+func deletePortVlanConfig(ctx context.Context) {
+	metadata.Map(ctx, true).Delete(portVlanConfigKey{})
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// captureReadTimeout bounds a read on the capture socket, so that the capture limits are checked
+const captureReadTimeout = 100 * time.Millisecond
+
+// Capture mirrors the traffic of the connection to a capture veth pair for duration or until maxPackets frames
+// are captured (no packet limit when 0), and writes the frames in pcap format to w. The capture port is removed
+// from the bridge afterwards. A connection which is already mirrored can't be captured.
+func (m *Mirrors) Capture(ctx context.Context, connID string, w io.Writer, duration time.Duration, maxPackets int) error {
+	logger := log.FromContext(ctx).WithField("Mirrors", "Capture")
+	if m.isMirrored(connID) {
+		return errors.Errorf("connection %s is already mirrored", connID)
+	}
+	portName, peerName := getCaptureVethNames(connID)
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: portName}, PeerName: peerName}
+	if err := netlink.LinkAdd(veth); err != nil {
+		return errors.Wrapf(err, "failed to create capture veth pair %s", portName)
+	}
+	defer func() {
+		if err := netlink.LinkDel(veth); err != nil {
+			logger.Errorf("failed to delete capture veth pair %s: %v", portName, err)
+		}
+	}()
+	peer, err := netlink.LinkByName(peerName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find capture interface %s", peerName)
+	}
+	for _, link := range []netlink.Link{veth, peer} {
+		if err = netlink.LinkSetUp(link); err != nil {
+			return errors.Wrapf(err, "failed to set capture interface %s up", link.Attrs().Name)
+		}
+	}
+
+	fd, err := openCaptureSocket(peer.Attrs().Index)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = unix.Close(fd) }()
+
+	// the capture port is created by the forwarder, it needs no allowance
+	if err = m.start(ctx, connID, &Target{Port: portName}, duration); err != nil {
+		return err
+	}
+	defer m.stopIfMirrored(ctx, connID)
+
+	pcap, err := newPcapWriter(w)
+	if err != nil {
+		return err
+	}
+	logger.Infof("capturing connection %s for %s", connID, duration)
+	return readFrames(ctx, fd, pcap, time.Now().Add(duration), maxPackets)
+}
+
+func openCaptureSocket(ifindex int) (int, error) {
+	protocol := htons(unix.ETH_P_ALL)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, int(protocol))
+	if err != nil {
+		return -1, errors.Wrap(err, "failed to open capture socket")
+	}
+	if err = unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: protocol, Ifindex: ifindex}); err != nil {
+		_ = unix.Close(fd)
+		return -1, errors.Wrap(err, "failed to bind capture socket")
+	}
+	timeout := unix.NsecToTimeval(captureReadTimeout.Nanoseconds())
+	if err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		_ = unix.Close(fd)
+		return -1, errors.Wrap(err, "failed to set capture socket timeout")
+	}
+	return fd, nil
+}
+
+func readFrames(ctx context.Context, fd int, pcap *pcapWriter, deadline time.Time, maxPackets int) error {
+	buf := make([]byte, pcapSnapLen)
+	for packets := 0; maxPackets == 0 || packets < maxPackets; {
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			return nil
+		}
+		n, from, err := unix.Recvfrom(fd, buf, unix.MSG_TRUNC)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return errors.Wrap(err, "failed to read capture socket")
+		}
+		// the frames sent by the kernel on the capture interface are not part of the connection traffic
+		if sll, ok := from.(*unix.SockaddrLinklayer); ok && sll.Pkttype == unix.PACKET_OUTGOING {
+			continue
+		}
+		captured := n
+		if captured > len(buf) {
+			captured = len(buf)
+		}
+		if err = pcap.writeFrame(time.Now(), buf[:captured], n); err != nil {
+			return err
+		}
+		packets++
+	}
+	return nil
+}
+
+// getCaptureVethNames returns the names of the capture veth pair within the 15 characters of an interface name
+func getCaptureVethNames(connID string) (portName, peerName string) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(connID))
+	return fmt.Sprintf("nsmcap%08x", h.Sum32()), fmt.Sprintf("nsmcapp%08x", h.Sum32())
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package mirror
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	defaultCaptureDuration = 10 * time.Second
+	maxCaptureDuration     = 5 * time.Minute
+)
+
+// NewCaptureHandler returns an http handler capturing the traffic of a connection and responding with the pcap
+// file, e.g. GET /capture?id=<connection id>&duration=30s&packets=1000. The capture lasts 10s by default and at
+// most 5m, it is not limited in packets by default.
+func NewCaptureHandler(mirrors *Mirrors) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		connID := query.Get("id")
+		if connID == "" {
+			http.Error(w, "no connection id set", http.StatusBadRequest)
+			return
+		}
+		duration := defaultCaptureDuration
+		if rawDuration := query.Get("duration"); rawDuration != "" {
+			var err error
+			if duration, err = time.ParseDuration(rawDuration); err != nil || duration <= 0 || duration > maxCaptureDuration {
+				http.Error(w, "invalid duration "+rawDuration, http.StatusBadRequest)
+				return
+			}
+		}
+		var maxPackets int
+		if rawPackets := query.Get("packets"); rawPackets != "" {
+			var err error
+			if maxPackets, err = strconv.Atoi(rawPackets); err != nil || maxPackets < 0 {
+				http.Error(w, "invalid packets "+rawPackets, http.StatusBadRequest)
+				return
+			}
+		}
+
+		if _, ok := mirrors.registry.Load(connID); !ok {
+			http.Error(w, "unknown connection "+connID, http.StatusNotFound)
+			return
+		}
+
+		response := &pcapResponse{w: w}
+		if err := mirrors.Capture(r.Context(), connID, response, duration, maxPackets); err != nil {
+			log.FromContext(r.Context()).WithField("captureHandler", "ServeHTTP").Errorf("capture of %s failed: %v", connID, err)
+			// the status can only be set while nothing is written
+			if !response.written {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		}
+	})
+}
+
+// pcapResponse sets the pcap file headers of the response on the first write
+type pcapResponse struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (p *pcapResponse) Write(data []byte) (int, error) {
+	if !p.written {
+		p.w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+		p.w.Header().Set("Content-Disposition", "attachment; filename=capture.pcap")
+		p.written = true
+	}
+	return p.w.Write(data)
+}
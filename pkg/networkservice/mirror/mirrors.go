@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/connections"
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// Target is the destination of the mirrored traffic, either a port like one end of a dedicated mirror veth pair or
+// an ERSPAN tunnel towards ERSPANRemoteIP
+type Target struct {
+	Port           string
+	ERSPANRemoteIP string
+	ERSPANKey      uint32
+}
+
+// session is an active mirror of a connection, targetPort is the port the forwarder added to the bridge as
+// mirror output
+type session struct {
+	mirrorName string
+	targetPort string
+	timer      *time.Timer
+}
+
+// Mirrors mirrors the traffic of the connections of a bridge with ovs Mirror records. The traffic is mirrored
+// only to the targets allowed by the options.
+type Mirrors struct {
+	bridgeName string
+	registry   *connections.Registry
+	opts       *mirrorsOptions
+	mutex      sync.Mutex
+	sessions   map[string]*session
+}
+
+// NewMirrors returns Mirrors of the connections of the bridge registered in registry
+func NewMirrors(bridgeName string, registry *connections.Registry, options ...Option) *Mirrors {
+	opts := &mirrorsOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &Mirrors{
+		bridgeName: bridgeName,
+		registry:   registry,
+		opts:       opts,
+		sessions:   make(map[string]*session),
+	}
+}
+
+// Start mirrors the traffic of the connection to the target, an active mirror of the connection is replaced.
+// The mirror is removed when the connection is closed or, if timeout is not 0, when the timeout expires.
+func (m *Mirrors) Start(ctx context.Context, connID string, target *Target, timeout time.Duration) error {
+	if err := m.checkTarget(target); err != nil {
+		return err
+	}
+	return m.start(ctx, connID, target, timeout)
+}
+
+func (m *Mirrors) start(ctx context.Context, connID string, target *Target, timeout time.Duration) error {
+	logger := log.FromContext(ctx).WithField("Mirrors", "Start")
+	portName, err := m.getMirroredPort(connID)
+	if err != nil {
+		return err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if s, ok := m.sessions[connID]; ok {
+		_ = m.stop(logger, connID, s)
+	}
+
+	s := &session{mirrorName: getMirrorName(connID)}
+	switch {
+	case target.ERSPANRemoteIP != "":
+		erspanPort := getERSPANPortName(connID)
+		if err = ovsutil.AddERSPANPort(m.bridgeName, erspanPort, target.ERSPANRemoteIP, target.ERSPANKey); err != nil {
+			return err
+		}
+		s.targetPort = erspanPort
+	case target.Port != "":
+		if err = ovsutil.AddMirrorPort(m.bridgeName, target.Port); err != nil {
+			return err
+		}
+		s.targetPort = target.Port
+	default:
+		return errors.New("no mirror target set")
+	}
+	if err = ovsutil.AddMirror(m.bridgeName, s.mirrorName, portName, s.targetPort); err != nil {
+		m.deleteTargetPort(logger, s)
+		return err
+	}
+	if timeout > 0 {
+		s.timer = time.AfterFunc(timeout, func() {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+			// the session may have been replaced in the meantime
+			if m.sessions[connID] == s {
+				logger.Infof("mirror of connection %s expired", connID)
+				_ = m.stop(logger, connID, s)
+			}
+		})
+	}
+	m.sessions[connID] = s
+	logger.Infof("mirroring port %s of connection %s to %s", portName, connID, s.targetPort)
+	return nil
+}
+
+// Stop removes the mirror of the connection
+func (m *Mirrors) Stop(ctx context.Context, connID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	s, ok := m.sessions[connID]
+	if !ok {
+		return errors.Errorf("connection %s is not mirrored", connID)
+	}
+	return m.stop(log.FromContext(ctx).WithField("Mirrors", "Stop"), connID, s)
+}
+
+func (m *Mirrors) isMirrored(connID string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	_, ok := m.sessions[connID]
+	return ok
+}
+
+// stopIfMirrored removes the mirror of the connection if it is mirrored
+func (m *Mirrors) stopIfMirrored(ctx context.Context, connID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if s, ok := m.sessions[connID]; ok {
+		_ = m.stop(log.FromContext(ctx).WithField("Mirrors", "stopIfMirrored"), connID, s)
+	}
+}
+
+// getMirroredPort returns the port carrying the traffic of the connection only, the nsc port is preferred.
+// Tunnel and VLAN trunk ports are shared between connections.
+func (m *Mirrors) getMirroredPort(connID string) (string, error) {
+	c, ok := m.registry.Load(connID)
+	if !ok {
+		return "", errors.Errorf("unknown connection %s", connID)
+	}
+	for _, port := range []*ifnames.OvsPortInfo{&c.ServerPort, c.ClientPort} {
+		if port != nil && !port.IsTunnelPort && port.VlanID == 0 && port.ServiceVlanID == 0 {
+			return port.PortName, nil
+		}
+	}
+	return "", errors.Errorf("connection %s has no port to mirror", connID)
+}
+
+// checkTarget returns error if the target is not allowed by the options
+func (m *Mirrors) checkTarget(target *Target) error {
+	if target.ERSPANRemoteIP != "" {
+		remoteIP := net.ParseIP(target.ERSPANRemoteIP)
+		if remoteIP == nil {
+			return errors.Errorf("invalid erspan remote ip %s", target.ERSPANRemoteIP)
+		}
+		for _, network := range m.opts.allowedERSPANNetworks {
+			if network.Contains(remoteIP) {
+				return nil
+			}
+		}
+		return errors.Errorf("erspan remote ip %s is not allowed", target.ERSPANRemoteIP)
+	}
+	if target.Port != "" {
+		for _, portName := range m.opts.allowedPorts {
+			if target.Port == portName {
+				return nil
+			}
+		}
+		return errors.Errorf("mirror port %s is not allowed", target.Port)
+	}
+	return nil
+}
+
+func (m *Mirrors) stop(logger log.Logger, connID string, s *session) error {
+	delete(m.sessions, connID)
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	err := ovsutil.DeleteMirror(m.bridgeName, s.mirrorName)
+	if err != nil {
+		logger.Errorf("%v", err)
+	}
+	m.deleteTargetPort(logger, s)
+	return err
+}
+
+// deleteTargetPort deletes the target port of the session from the bridge unless another session mirrors to it
+func (m *Mirrors) deleteTargetPort(logger log.Logger, s *session) {
+	for _, other := range m.sessions {
+		if other.targetPort == s.targetPort {
+			return
+		}
+	}
+	if err := ovsutil.DeletePort(m.bridgeName, s.targetPort); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
+
+func getMirrorName(connID string) string {
+	return "nsm-mirror-" + connID
+}
+
+// getERSPANPortName returns a port name derived from the connection ID within the 15 characters of an interface name
+func getERSPANPortName(connID string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(connID))
+	return fmt.Sprintf("erspan%08x", h.Sum32())
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"net"
+)
+
+// Option is an option pattern for NewMirrors
+type Option func(o *mirrorsOptions)
+
+// WithAllowedPorts allows the traffic to be mirrored to the given ports, no port is allowed by default
+func WithAllowedPorts(portNames ...string) Option {
+	return func(o *mirrorsOptions) {
+		o.allowedPorts = append(o.allowedPorts, portNames...)
+	}
+}
+
+// WithAllowedERSPANNetworks allows the traffic to be mirrored to ERSPAN remote IPs within the given networks, no
+// remote IP is allowed by default
+func WithAllowedERSPANNetworks(networks ...*net.IPNet) Option {
+	return func(o *mirrorsOptions) {
+		o.allowedERSPANNetworks = append(o.allowedERSPANNetworks, networks...)
+	}
+}
+
+type mirrorsOptions struct {
+	allowedPorts          []string
+	allowedERSPANNetworks []*net.IPNet
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	pcapMagic        = 0xa1b2c3d4
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+	pcapSnapLen      = 65535
+	pcapLinkEthernet = 1
+)
+
+// pcapWriter writes ethernet frames in pcap format
+type pcapWriter struct {
+	w io.Writer
+}
+
+func newPcapWriter(w io.Writer) (*pcapWriter, error) {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:4], pcapMagic)
+	binary.LittleEndian.PutUint16(header[4:6], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(header[6:8], pcapVersionMinor)
+	// thiszone and sigfigs are 0
+	binary.LittleEndian.PutUint32(header[16:20], pcapSnapLen)
+	binary.LittleEndian.PutUint32(header[20:24], pcapLinkEthernet)
+	if _, err := w.Write(header); err != nil {
+		return nil, errors.Wrap(err, "failed to write pcap header")
+	}
+	return &pcapWriter{w: w}, nil
+}
+
+func (p *pcapWriter) writeFrame(timestamp time.Time, frame []byte, length int) error {
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(timestamp.Unix()))
+	binary.LittleEndian.PutUint32(header[4:8], uint32(timestamp.Nanosecond()/int(time.Microsecond)))
+	binary.LittleEndian.PutUint32(header[8:12], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(header[12:16], uint32(length))
+	if _, err := p.w.Write(header); err != nil {
+		return errors.Wrap(err, "failed to write pcap record")
+	}
+	if _, err := p.w.Write(frame); err != nil {
+		return errors.Wrap(err, "failed to write pcap record")
+	}
+	return nil
+}
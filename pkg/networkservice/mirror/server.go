@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mirror provides chain element which mirrors the traffic of a connection in the forwarder to a
+// dedicated port or an ERSPAN tunnel, triggered by connection labels or through the Mirrors API
+package mirror
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PortLabel - name of the port, e.g. one end of a veth pair, receiving the mirrored traffic of the connection,
+	// see WithAllowedPorts
+	PortLabel = "mirror.port"
+	// ERSPANRemoteIPLabel - remote IP of the ERSPAN tunnel receiving the mirrored traffic of the connection, see
+	// WithAllowedERSPANNetworks
+	ERSPANRemoteIPLabel = "mirror.erspan.remote-ip"
+	// ERSPANKeyLabel - key of the ERSPAN tunnel, 0 by default
+	ERSPANKeyLabel = "mirror.erspan.key"
+	// TimeoutLabel - duration after which the mirror is removed, e.g. "10m". The mirror lasts as long as
+	// the connection by default.
+	TimeoutLabel = "mirror.timeout"
+)
+
+type mirrorServer struct {
+	mirrors *Mirrors
+}
+
+// NewServer - returns a server chain element mirroring the connections carrying mirror labels, the mirror of a
+// connection is removed when it is closed. It must precede the connections server of the mirrors registry.
+func NewServer(mirrors *Mirrors) networkservice.NetworkServiceServer {
+	return &mirrorServer{mirrors: mirrors}
+}
+
+func (s *mirrorServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	logger := log.FromContext(ctx).WithField("mirrorServer", "Request")
+
+	target, timeout, err := getLabelTarget(conn.GetLabels())
+	if err != nil {
+		// mirroring is a troubleshooting aid, it doesn't fail the connection
+		logger.Warnf("connection %s is not mirrored: %v", conn.GetId(), err)
+		return conn, nil
+	}
+	if target == nil || s.mirrors.isMirrored(conn.GetId()) {
+		return conn, nil
+	}
+	if err = s.mirrors.Start(ctx, conn.GetId(), target, timeout); err != nil {
+		logger.Warnf("connection %s is not mirrored: %v", conn.GetId(), err)
+	}
+	return conn, nil
+}
+
+func (s *mirrorServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	s.mirrors.stopIfMirrored(ctx, conn.GetId())
+	return next.Server(ctx).Close(ctx, conn)
+}
+
+// getLabelTarget returns the mirror target and timeout carried in the connection labels, nil target when the
+// connection is not to be mirrored
+func getLabelTarget(labels map[string]string) (*Target, time.Duration, error) {
+	target := &Target{Port: labels[PortLabel], ERSPANRemoteIP: labels[ERSPANRemoteIPLabel]}
+	if target.Port == "" && target.ERSPANRemoteIP == "" {
+		return nil, 0, nil
+	}
+	if rawKey, ok := labels[ERSPANKeyLabel]; ok {
+		key, err := strconv.ParseUint(rawKey, 10, 32)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid value %q of label %s", rawKey, ERSPANKeyLabel)
+		}
+		target.ERSPANKey = uint32(key)
+	}
+	var timeout time.Duration
+	if rawTimeout, ok := labels[TimeoutLabel]; ok {
+		var err error
+		if timeout, err = time.ParseDuration(rawTimeout); err != nil {
+			return nil, 0, errors.Wrapf(err, "invalid value %q of label %s", rawTimeout, TimeoutLabel)
+		}
+	}
+	return target, timeout, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qos
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+var uuidRegexp = regexp.MustCompile("[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}")
+
+// applyPolicy configures ingress policing on the ovs interface and an egress linux-htb QoS with a single
+// queue on the ovs port.
+func applyPolicy(logger log.Logger, portName string, policy *Policy) error {
+	stdout, stderr, err := util.RunOVSVsctl("set", "interface", portName,
+		fmt.Sprintf("ingress_policing_rate=%d", policy.IngressPolicingRate),
+		fmt.Sprintf("ingress_policing_burst=%d", policy.IngressPolicingBurst))
+	if err != nil {
+		logger.Errorf("Failed to set ingress policing on %s, stdout: %q, stderr: %q, error: %v", portName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to set ingress policing on %s, stdout: %q, stderr: %q", portName, stdout, stderr)
+	}
+	if policy.EgressMaxRate == 0 && policy.EgressMinRate == 0 {
+		return nil
+	}
+
+	qosArgs := []string{"--", "set", "port", portName, "qos=@qos",
+		"--", "--id=@qos", "create", "qos", "type=linux-htb", "queues:0=@queue",
+		"--", "--id=@queue", "create", "queue"}
+	if policy.EgressMaxRate > 0 {
+		qosArgs = append(qosArgs, fmt.Sprintf("other-config:max-rate=%d", policy.EgressMaxRate))
+	}
+	if policy.EgressMinRate > 0 {
+		qosArgs = append(qosArgs, fmt.Sprintf("other-config:min-rate=%d", policy.EgressMinRate))
+	}
+	stdout, stderr, err = util.RunOVSVsctl(qosArgs...)
+	if err != nil {
+		logger.Errorf("Failed to set egress qos on %s, stdout: %q, stderr: %q, error: %v", portName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to set egress qos on %s, stdout: %q, stderr: %q", portName, stdout, stderr)
+	}
+	return nil
+}
+
+// removePolicy disables ingress policing on the ovs interface, detaches the QoS from the ovs port and
+// destroys the QoS and Queue records, which are not garbage collected by ovsdb.
+func removePolicy(logger log.Logger, portName string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "set", "interface", portName,
+		"ingress_policing_rate=0", "ingress_policing_burst=0")
+	if err != nil {
+		logger.Errorf("Failed to reset ingress policing on %s, stdout: %q, stderr: %q, error: %v", portName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to reset ingress policing on %s, stdout: %q, stderr: %q", portName, stdout, stderr)
+	}
+
+	stdout, stderr, err = util.RunOVSVsctl("--if-exists", "get", "port", portName, "qos")
+	if err != nil {
+		return errors.Wrapf(err, "failed to get qos of %s, stderr: %q", portName, stderr)
+	}
+	qosUUID := uuidRegexp.FindString(stdout)
+	if qosUUID == "" {
+		return nil
+	}
+	queues, stderr, err := util.RunOVSVsctl("get", "qos", qosUUID, "queues")
+	if err != nil {
+		return errors.Wrapf(err, "failed to get queues of qos %s, stderr: %q", qosUUID, stderr)
+	}
+
+	destroyArgs := []string{"--", "clear", "port", portName, "qos", "--", "destroy", "qos", qosUUID}
+	for _, queueUUID := range uuidRegexp.FindAllString(queues, -1) {
+		destroyArgs = append(destroyArgs, "--", "destroy", "queue", queueUUID)
+	}
+	stdout, stderr, err = util.RunOVSVsctl(destroyArgs...)
+	if err != nil {
+		logger.Errorf("Failed to remove egress qos from %s, stdout: %q, stderr: %q, error: %v", portName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to remove egress qos from %s, stdout: %q, stderr: %q", portName, stdout, stderr)
+	}
+	return nil
+}
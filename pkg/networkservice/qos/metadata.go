@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qos
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// store stores the policy applied on the connection port
+func store(ctx context.Context, isClient bool, policy *Policy) {
+	metadata.Map(ctx, isClient).Store(key{}, policy)
+}
+
+// load retrieves the policy applied on the connection port
+func load(ctx context.Context, isClient bool) (value *Policy, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*Policy)
+	return value, ok
+}
+
+// loadAndDelete retrieves the policy applied on the connection port and deletes it from the cache
+func loadAndDelete(ctx context.Context, isClient bool) (value *Policy, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*Policy)
+	return value, ok
+}
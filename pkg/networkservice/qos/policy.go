@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qos
+
+import (
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/pkg/errors"
+)
+
+// Connection labels overriding the network service policy
+const (
+	// IngressPolicingRateLabel - maximum rate in kbps of the traffic received from the pod
+	IngressPolicingRateLabel = "qos.ingress-policing-rate"
+	// IngressPolicingBurstLabel - maximum burst size in kb of the traffic received from the pod
+	IngressPolicingBurstLabel = "qos.ingress-policing-burst"
+	// EgressMaxRateLabel - maximum rate in bps of the traffic sent to the pod
+	EgressMaxRateLabel = "qos.egress-max-rate"
+	// EgressMinRateLabel - guaranteed rate in bps of the traffic sent to the pod
+	EgressMinRateLabel = "qos.egress-min-rate"
+)
+
+// Policy describes bandwidth limits of a connection port. Zero value disables the respective limit.
+type Policy struct {
+	// IngressPolicingRate - maximum rate in kbps of the traffic received from the pod
+	IngressPolicingRate uint64
+	// IngressPolicingBurst - maximum burst size in kb of the traffic received from the pod
+	IngressPolicingBurst uint64
+	// EgressMaxRate - maximum rate in bps of the traffic sent to the pod
+	EgressMaxRate uint64
+	// EgressMinRate - guaranteed rate in bps of the traffic sent to the pod
+	EgressMinRate uint64
+}
+
+// IsEmpty returns true if the policy doesn't limit anything
+func (p *Policy) IsEmpty() bool {
+	return p == nil || *p == Policy{}
+}
+
+// getPolicy returns the policy of the network service overridden by the connection labels
+func getPolicy(conn *networkservice.Connection, servicePolicies map[string]*Policy) (*Policy, error) {
+	policy := &Policy{}
+	if servicePolicy, ok := servicePolicies[conn.GetNetworkService()]; ok && servicePolicy != nil {
+		*policy = *servicePolicy
+	}
+	for label, value := range map[string]*uint64{
+		IngressPolicingRateLabel:  &policy.IngressPolicingRate,
+		IngressPolicingBurstLabel: &policy.IngressPolicingBurst,
+		EgressMaxRateLabel:        &policy.EgressMaxRate,
+		EgressMinRateLabel:        &policy.EgressMinRate,
+	} {
+		rawValue, ok := conn.GetLabels()[label]
+		if !ok {
+			continue
+		}
+		parsedValue, err := strconv.ParseUint(rawValue, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value %q of label %s", rawValue, label)
+		}
+		*value = parsedValue
+	}
+	if policy.EgressMinRate > 0 && policy.EgressMaxRate > 0 && policy.EgressMinRate > policy.EgressMaxRate {
+		return nil, errors.Errorf("egress min rate %d is greater than egress max rate %d", policy.EgressMinRate, policy.EgressMaxRate)
+	}
+	return policy, nil
+}
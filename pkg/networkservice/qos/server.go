@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qos provides chain element which limits the bandwidth of a connection port by ovs ingress
+// policing and egress QoS/Queue records, based on connection labels or network service policy
+package qos
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+type qosServer struct {
+	servicePolicies map[string]*Policy
+}
+
+// NewServer - returns a server chain element applying bandwidth limits on the ovs port of the
+// client connection. servicePolicies holds default policy per network service, which can be
+// overridden by the connection labels.
+func NewServer(servicePolicies map[string]*Policy) networkservice.NetworkServiceServer {
+	return &qosServer{servicePolicies: servicePolicies}
+}
+
+func (q *qosServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	logger := log.FromContext(ctx).WithField("qosServer", "Request")
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = q.update(ctx, logger, conn); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+		if _, closeErr := q.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (q *qosServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	logger := log.FromContext(ctx).WithField("qosServer", "Close")
+	_, err := next.Server(ctx).Close(ctx, conn)
+
+	var qosErr error
+	if _, ok := loadAndDelete(ctx, metadata.IsClient(q)); ok {
+		if ovsPortInfo, exists := ifnames.Load(ctx, metadata.IsClient(q)); exists {
+			qosErr = removePolicy(logger, ovsPortInfo.PortName)
+		}
+	}
+
+	if err != nil && qosErr != nil {
+		return nil, errors.Wrap(err, qosErr.Error())
+	}
+	if qosErr != nil {
+		return nil, qosErr
+	}
+	return &empty.Empty{}, err
+}
+
+// update applies the connection policy on the ovs port, it is reapplied only when the policy
+// is changed on refresh.
+func (q *qosServer) update(ctx context.Context, logger log.Logger, conn *networkservice.Connection) error {
+	if kernel.ToMechanism(conn.GetMechanism()) == nil {
+		return nil
+	}
+	ovsPortInfo, ok := ifnames.Load(ctx, metadata.IsClient(q))
+	if !ok {
+		return nil
+	}
+	policy, err := getPolicy(conn, q.servicePolicies)
+	if err != nil {
+		return err
+	}
+	appliedPolicy, applied := load(ctx, metadata.IsClient(q))
+	if applied && *appliedPolicy == *policy {
+		return nil
+	}
+	if ovsPortInfo.VlanID > 0 && !policy.IsEmpty() {
+		logger.Warnf("qos is not supported on shared vlan trunk port %s", ovsPortInfo.PortName)
+		return nil
+	}
+
+	if applied {
+		if err = removePolicy(logger, ovsPortInfo.PortName); err != nil {
+			return err
+		}
+		loadAndDelete(ctx, metadata.IsClient(q))
+	}
+	if policy.IsEmpty() {
+		return nil
+	}
+	if err = applyPolicy(logger, ovsPortInfo.PortName, policy); err != nil {
+		// the policy is not stored, so roll back the part of it which may already be applied
+		if rmErr := removePolicy(logger, ovsPortInfo.PortName); rmErr != nil {
+			return errors.Wrapf(err, "failed to roll back qos policy: %s", rmErr.Error())
+		}
+		return err
+	}
+	store(ctx, metadata.IsClient(q), policy)
+	logger.Infof("applied qos policy %+v on port %s", *policy, ovsPortInfo.PortName)
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	serverPrefix = "server_"
+	clientPrefix = "client_"
+)
+
+// collector periodically publishes the statistics of a connection through the monitor connection stream
+type collector struct {
+	mutex      sync.Mutex
+	conn       *networkservice.Connection
+	serverPort ifnames.OvsPortInfo
+	clientPort *ifnames.OvsPortInfo
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// update sets the latest connection and its ports, they are refreshed on every Request as the
+// client side may change on heal
+func (c *collector) update(conn *networkservice.Connection, serverPort, clientPort *ifnames.OvsPortInfo) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.conn = conn.Clone()
+	c.serverPort = *serverPort
+	c.clientPort = nil
+	if clientPort != nil {
+		port := *clientPort
+		c.clientPort = &port
+	}
+}
+
+func (c *collector) start(chainCtx context.Context, bridgeName string, interval time.Duration, eventConsumer monitor.EventConsumer) {
+	ctx, cancel := context.WithCancel(chainCtx)
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.publish(ctx, bridgeName, eventConsumer)
+			}
+		}
+	}()
+}
+
+// stop stops the collection and waits for an ongoing publish to complete
+func (c *collector) stop() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+}
+
+func (c *collector) publish(ctx context.Context, bridgeName string, eventConsumer monitor.EventConsumer) {
+	c.mutex.Lock()
+	conn := c.conn.Clone()
+	serverPort := c.serverPort
+	clientPort := c.clientPort
+	c.mutex.Unlock()
+
+	logger := log.FromContext(ctx).WithField("statsServer", "publish").WithField("connectionID", conn.GetId())
+	metrics := getMetrics(logger, bridgeName, &serverPort, clientPort)
+	if !setMetrics(conn, metrics) {
+		return
+	}
+	err := eventConsumer.Send(&networkservice.ConnectionEvent{
+		Type:        networkservice.ConnectionEventType_UPDATE,
+		Connections: map[string]*networkservice.Connection{conn.GetId(): conn},
+	})
+	if err != nil {
+		logger.Warnf("failed to send statistics update: %v", err)
+	}
+}
+
+// getMetrics returns the sampling IDs of the connection and the rx/tx packets, bytes and drops of both the client
+// (endpoint facing) and server (nsc facing) ports. Cross connected ports are measured on their flows as tunnel and
+// vlan trunk ports are shared between connections, otherwise the interface statistics are used.
+func getMetrics(logger log.Logger, bridgeName string, serverPort, clientPort *ifnames.OvsPortInfo) map[string]string {
+	metrics := make(map[string]string)
+	addSamplingMetrics(metrics, serverPort)
+	if clientPort == nil || !serverPort.IsCrossConnected || !clientPort.IsCrossConnected {
+		addInterfaceMetrics(logger, metrics, serverPrefix, serverPort, true)
+		return metrics
+	}
+	addFlowMetrics(logger, metrics, bridgeName, serverPrefix, serverPort, clientPort)
+	addFlowMetrics(logger, metrics, bridgeName, clientPrefix, clientPort, serverPort)
+	addInterfaceMetrics(logger, metrics, serverPrefix, serverPort, false)
+	addInterfaceMetrics(logger, metrics, clientPrefix, clientPort, false)
+	return metrics
+}
+
+func addFlowMetrics(logger log.Logger, metrics map[string]string, bridgeName, prefix string, port, peerPort *ifnames.OvsPortInfo) {
+	rxPackets, rxBytes, err := ovsutil.GetFlowStatistics(bridgeName, getFlowMatch(port))
+	if err != nil {
+		logger.Warnf("failed to get flow statistics of port %s: %v", port.PortName, err)
+		return
+	}
+	txPackets, txBytes, err := ovsutil.GetFlowStatistics(bridgeName, getFlowMatch(peerPort))
+	if err != nil {
+		logger.Warnf("failed to get flow statistics of port %s: %v", peerPort.PortName, err)
+		return
+	}
+	metrics[prefix+"rx_packets"] = strconv.FormatUint(rxPackets, 10)
+	metrics[prefix+"rx_bytes"] = strconv.FormatUint(rxBytes, 10)
+	metrics[prefix+"tx_packets"] = strconv.FormatUint(txPackets, 10)
+	metrics[prefix+"tx_bytes"] = strconv.FormatUint(txBytes, 10)
+	if !port.IsPortSecured {
+		return
+	}
+	securityDrops, err := ovsutil.GetFlowDropStatistics(bridgeName, getFlowMatch(port))
+	if err != nil {
+		logger.Warnf("failed to get drop statistics of port %s: %v", port.PortName, err)
+		return
+	}
+	metrics[prefix+"security_drops"] = strconv.FormatUint(securityDrops, 10)
+}
+
+// addSamplingMetrics adds the IPFIX observation point ID of a sampled connection, which is also the cookie of its
+// flows, so that the flow records and the flows can be joined back to the connection
+func addSamplingMetrics(metrics map[string]string, port *ifnames.OvsPortInfo) {
+	if port.ObsPointID == 0 {
+		return
+	}
+	metrics["obs_point_id"] = strconv.FormatUint(uint64(port.ObsPointID), 10)
+	metrics["flow_cookie"] = fmt.Sprintf("0x%x", port.ObsPointID)
+}
+
+func addInterfaceMetrics(logger log.Logger, metrics map[string]string, prefix string, port *ifnames.OvsPortInfo, withCounters bool) {
+	// drops on a shared port can't be accounted to a single connection
+	if port.IsTunnelPort || port.VlanID > 0 || port.ServiceVlanID > 0 {
+		return
+	}
+	statistics, err := ovsutil.GetInterfaceStatistics(port.PortName)
+	if err != nil {
+		logger.Warnf("failed to get interface statistics of port %s: %v", port.PortName, err)
+		return
+	}
+	metrics[prefix+"drops"] = strconv.FormatUint(statistics["rx_dropped"]+statistics["tx_dropped"], 10)
+	if !withCounters {
+		return
+	}
+	for _, counter := range []string{"rx_packets", "rx_bytes", "tx_packets", "tx_bytes"} {
+		metrics[prefix+counter] = strconv.FormatUint(statistics[counter], 10)
+	}
+}
+
+// getFlowMatch returns the openflow match of the flows receiving the connection packets from the port
+func getFlowMatch(port *ifnames.OvsPortInfo) string {
+	switch {
+	case port.IsTunnelPort:
+		return fmt.Sprintf("in_port=%d,tun_id=%d", port.PortNo, port.VNI)
+	case port.ServiceVlanID > 0 && port.VlanID > 0:
+		return fmt.Sprintf("table=%d,in_port=%d,metadata=%d,dl_vlan=%d", ovsutil.QinQTable, port.PortNo, port.ServiceVlanID, port.VlanID)
+	case port.ServiceVlanID > 0:
+		return fmt.Sprintf("in_port=%d,dl_vlan=%d", port.PortNo, port.ServiceVlanID)
+	case port.VlanID > 0:
+		return fmt.Sprintf("in_port=%d,dl_vlan=%d", port.PortNo, port.VlanID)
+	default:
+		return fmt.Sprintf("in_port=%d", port.PortNo)
+	}
+}
+
+// setMetrics writes the metrics into the forwarder path segment of the connection
+func setMetrics(conn *networkservice.Connection, metrics map[string]string) bool {
+	if len(metrics) == 0 {
+		return false
+	}
+	path := conn.GetPath()
+	if int(path.GetIndex()) >= len(path.GetPathSegments()) {
+		return false
+	}
+	segment := path.GetPathSegments()[path.GetIndex()]
+	if segment.Metrics == nil {
+		segment.Metrics = make(map[string]string)
+	}
+	for name, value := range metrics {
+		segment.Metrics[name] = value
+	}
+	return true
+}
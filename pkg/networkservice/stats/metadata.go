@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// loadOrStore returns the collector of the connection if present, otherwise stores the given one
+func loadOrStore(ctx context.Context, isClient bool, c *collector) (value *collector, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadOrStore(key{}, c)
+	if !ok {
+		return c, ok
+	}
+	value, ok = rawValue.(*collector)
+	return value, ok
+}
+
+// loadAndDelete retrieves the collector of the connection and deletes it from the cache
+func loadAndDelete(ctx context.Context, isClient bool) (value *collector, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*collector)
+	return value, ok
+}
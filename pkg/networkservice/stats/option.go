@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import "time"
+
+// Option is an option pattern for stats server
+type Option func(o *statsOptions)
+
+// WithInterval sets the interval between two statistics collections, default is 10s
+func WithInterval(interval time.Duration) Option {
+	return func(o *statsOptions) {
+		o.interval = interval
+	}
+}
+
+type statsOptions struct {
+	interval time.Duration
+}
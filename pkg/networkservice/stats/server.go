@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats provides chain element which periodically publishes the traffic statistics of a
+// connection in the forwarder path segment metrics through the monitor connection stream
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/common/monitor"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/tools/ifnames"
+)
+
+const defaultInterval = 10 * time.Second
+
+type statsServer struct {
+	chainCtx   context.Context
+	bridgeName string
+	interval   time.Duration
+}
+
+// NewServer - returns a server chain element collecting the connection statistics from ovs flows
+// and interfaces every interval, the statistics are collected until the connection is closed or
+// chainCtx is done.
+func NewServer(chainCtx context.Context, bridgeName string, options ...Option) networkservice.NetworkServiceServer {
+	opts := &statsOptions{interval: defaultInterval}
+	for _, opt := range options {
+		opt(opts)
+	}
+	return &statsServer{
+		chainCtx:   chainCtx,
+		bridgeName: bridgeName,
+		interval:   opts.interval,
+	}
+}
+
+func (s *statsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	serverPort, ok := ifnames.Load(ctx, metadata.IsClient(s))
+	if !ok {
+		return conn, nil
+	}
+	clientPort, _ := ifnames.Load(ctx, true)
+
+	c, loaded := loadOrStore(ctx, metadata.IsClient(s), &collector{})
+	c.update(conn, serverPort, clientPort)
+	if loaded {
+		return conn, nil
+	}
+	eventConsumer, ok := monitor.LoadEventConsumer(ctx, metadata.IsClient(s))
+	if !ok {
+		log.FromContext(ctx).WithField("statsServer", "Request").Warnf("no monitor event consumer, statistics of %s are not published",
+			conn.GetId())
+		return conn, nil
+	}
+	c.start(s.chainCtx, s.bridgeName, s.interval, eventConsumer)
+
+	return conn, nil
+}
+
+func (s *statsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if c, ok := loadAndDelete(ctx, metadata.IsClient(s)); ok {
+		c.stop()
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
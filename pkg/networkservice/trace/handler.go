@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// NewHandler returns an http handler tracing a packet of a connection and responding with the json trace result,
+// e.g. GET /debug/trace?id=<connection id>&direction=to-endpoint&protocol=tcp&port=443
+func NewHandler(tracer *Tracer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		request := &Request{
+			ConnID:    query.Get("id"),
+			Direction: query.Get("direction"),
+			Protocol:  query.Get("protocol"),
+		}
+		if request.ConnID == "" {
+			http.Error(w, "no connection id set", http.StatusBadRequest)
+			return
+		}
+		if request.Direction == "" {
+			request.Direction = DirectionToEndpoint
+		}
+		if rawPort := query.Get("port"); rawPort != "" {
+			port, err := strconv.ParseUint(rawPort, 10, 16)
+			if err != nil {
+				http.Error(w, "invalid port "+rawPort, http.StatusBadRequest)
+				return
+			}
+			request.DstPort = uint16(port)
+		}
+		result, err := tracer.Trace(r.Context(), request)
+		if err != nil {
+			log.FromContext(r.Context()).WithField("traceHandler", "ServeHTTP").Errorf("trace of %s failed: %v", request.ConnID, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(result); err != nil {
+			log.FromContext(r.Context()).WithField("traceHandler", "ServeHTTP").Errorf("failed to write trace of %s: %v", request.ConnID, err)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trace traces synthesized packets of the forwarder connections through the ovs datapath with
+// ofproto/trace
+package trace
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-ovs/pkg/networkservice/connections"
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	// DirectionToEndpoint - packets sent by the nsc towards the endpoint
+	DirectionToEndpoint = "to-endpoint"
+	// DirectionToClient - packets sent by the endpoint towards the nsc
+	DirectionToClient = "to-client"
+)
+
+// Request describes the packet to trace
+type Request struct {
+	ConnID    string
+	Direction string
+	// Protocol of the packet, icmp by default, or tcp, udp
+	Protocol string
+	// DstPort is the tcp or udp destination port
+	DstPort uint16
+}
+
+// Tracer traces synthesized packets of the connections of a bridge with ofproto/trace
+type Tracer struct {
+	bridgeName string
+	registry   *connections.Registry
+}
+
+// NewTracer returns Tracer of the connections of the bridge registered in registry
+func NewTracer(bridgeName string, registry *connections.Registry) *Tracer {
+	return &Tracer{
+		bridgeName: bridgeName,
+		registry:   registry,
+	}
+}
+
+// Trace synthesizes the packet of the request from the ovs ports and the ip context of the connection, runs
+// ofproto/trace and returns its result
+func (t *Tracer) Trace(ctx context.Context, request *Request) (*ovsutil.TraceResult, error) {
+	c, ok := t.registry.Load(request.ConnID)
+	if !ok {
+		return nil, errors.Errorf("unknown connection %s", request.ConnID)
+	}
+	flow, err := getFlow(c, request)
+	if err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).WithField("Tracer", "Trace").Debugf("tracing %s on %s", flow, t.bridgeName)
+	return ovsutil.Trace(t.bridgeName, flow)
+}
+
+// getFlow returns the ofproto/trace flow of the packet received on the ingress port of the direction
+func getFlow(c *connections.Connection, request *Request) (string, error) {
+	ethernetContext := c.Conn.GetContext().GetEthernetContext()
+	ipContext := c.Conn.GetContext().GetIpContext()
+	port := &c.ServerPort
+	srcMac, dstMac := ethernetContext.GetSrcMac(), ethernetContext.GetDstMac()
+	srcIPs, dstIPs := ipContext.GetSrcIpAddrs(), ipContext.GetDstIpAddrs()
+	switch request.Direction {
+	case DirectionToEndpoint:
+	case DirectionToClient:
+		if c.ClientPort == nil {
+			return "", errors.Errorf("connection %s has no endpoint port", request.ConnID)
+		}
+		port = c.ClientPort
+		srcMac, dstMac = dstMac, srcMac
+		srcIPs, dstIPs = dstIPs, srcIPs
+	default:
+		return "", errors.Errorf("unknown direction %q", request.Direction)
+	}
+	if port.ServiceVlanID > 0 {
+		return "", errors.New("trace of QinQ connections is not supported")
+	}
+
+	fields := []string{fmt.Sprintf("in_port=%d", port.PortNo)}
+	if port.IsTunnelPort {
+		fields = append(fields, fmt.Sprintf("tun_id=%d", port.VNI))
+	}
+	if port.VlanID > 0 {
+		fields = append(fields, fmt.Sprintf("dl_vlan=%d", port.VlanID))
+	}
+	if srcMac != "" {
+		fields = append(fields, "dl_src="+srcMac)
+	}
+	if dstMac != "" {
+		fields = append(fields, "dl_dst="+dstMac)
+	}
+	ipFields, err := getIPFields(request, srcIPs, dstIPs)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(append(fields, ipFields...), ","), nil
+}
+
+// getIPFields returns the l3/l4 fields of the packet from the first addresses of the ip context, none for l2 only
+// connections
+func getIPFields(request *Request, srcIPs, dstIPs []string) ([]string, error) {
+	if len(srcIPs) == 0 || len(dstIPs) == 0 {
+		return nil, nil
+	}
+	srcIP, _, err := net.ParseCIDR(srcIPs[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid source address %s", srcIPs[0])
+	}
+	dstIP, _, err := net.ParseCIDR(dstIPs[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid destination address %s", dstIPs[0])
+	}
+	isIPv6 := srcIP.To4() == nil
+	protocol := request.Protocol
+	switch {
+	case protocol == "" || protocol == "icmp":
+		protocol = "icmp"
+		if isIPv6 {
+			protocol = "icmp6"
+		}
+	case protocol == "tcp" || protocol == "udp":
+		if isIPv6 {
+			protocol += "6"
+		}
+	default:
+		return nil, errors.Errorf("unknown protocol %q", request.Protocol)
+	}
+	fields := []string{protocol}
+	if isIPv6 {
+		fields = append(fields, "ipv6_src="+srcIP.String(), "ipv6_dst="+dstIP.String())
+	} else {
+		fields = append(fields, "nw_src="+srcIP.String(), "nw_dst="+dstIP.String())
+	}
+	if request.DstPort > 0 && (strings.HasPrefix(protocol, "tcp") || strings.HasPrefix(protocol, "udp")) {
+		fields = append(fields, fmt.Sprintf("tp_dst=%d", request.DstPort))
+	}
+	return fields, nil
+}
@@ -27,16 +27,24 @@ import (
 
 type key struct{}
 
-// OvsPortInfo ovs port info container
+// OvsPortInfo ovs port info container, LinkedPortName is the port behind the VLAN of a shared link port, e.g. the
+// vhost-user port behind its VLAN of the vhost-user bridge link
 type OvsPortInfo struct {
 	PortName         string
+	LinkedPortName   string
 	PortNo           int
 	VlanID           uint32
+	ServiceVlanID    uint32
 	IsTunnelPort     bool
 	IsVfRepresentor  bool
 	IsCrossConnected bool
 	IsL2Connect      bool
 	VNI              uint32
+	MeterID          uint32
+	IsPortSecured    bool
+	CtZone           uint32
+	DomainMemberID   uint32
+	ObsPointID       uint32
 }
 
 // Store stores ovsPortInfo for the given cross connect, isClient identfies which connection it is.
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package l2config provides the declarative YAML/JSON config of the l2 connection points used for VLAN breakout
+package l2config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+const (
+	maxVlanID = 4095
+	maxMTU    = 65535
+)
+
+// Config contains the list of l2 connection points
+type Config struct {
+	ConnectionPoints []*ConnectionPoint `json:"connectionPoints"`
+}
+
+// ConnectionPoint contains the config of an l2 connection point
+type ConnectionPoint struct {
+	// Selector is the value of the "via" connection label selecting the connection point
+	Selector string `json:"selector"`
+	Bridge   string `json:"bridge"`
+	// Labels select the connection point when all of them match the connection labels
+	Labels map[string]string `json:"labels,omitempty"`
+	// Default selects the connection point when no other one matches
+	Default bool `json:"default,omitempty"`
+	// Interfaces are the uplink interface, or the bond members when Bond is set
+	Interfaces []string `json:"interfaces,omitempty"`
+	Bond       *Bond    `json:"bond,omitempty"`
+	// VlanRanges are the VLAN IDs allowed on the connection point, any VLAN ID when empty
+	VlanRanges    []*VlanRange `json:"vlanRanges,omitempty"`
+	ReservedVlans []uint32     `json:"reservedVlans,omitempty"`
+	MTU           uint32       `json:"mtu,omitempty"`
+}
+
+// Bond contains the ovs bond settings of a connection point
+type Bond struct {
+	Name     string `json:"name"`
+	Mode     string `json:"mode,omitempty"`
+	LACP     string `json:"lacp,omitempty"`
+	LACPTime string `json:"lacpTime,omitempty"`
+}
+
+// VlanRange contains the inclusive range of VLAN IDs allowed on a connection point
+type VlanRange struct {
+	Min uint32 `json:"min"`
+	Max uint32 `json:"max"`
+}
+
+// ReadFile reads and validates the config file, the returned connection points are keyed by selector
+func ReadFile(configFile string) (map[string]*ovsutil.L2ConnectionPoint, error) {
+	data, err := os.ReadFile(filepath.Clean(configFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading file: %v", configFile)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates YAML or JSON config, the returned connection points are keyed by selector
+func Parse(data []byte) (map[string]*ovsutil.L2ConnectionPoint, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling l2 connection point config")
+	}
+	l2Connections := make(map[string]*ovsutil.L2ConnectionPoint)
+	defaultSelector := ""
+	for idx, cp := range cfg.ConnectionPoints {
+		if cp == nil || cp.Selector == "" {
+			return nil, errors.Errorf("connection point %d has no selector set", idx)
+		}
+		if _, ok := l2Connections[cp.Selector]; ok {
+			return nil, errors.Errorf("connection point %s is defined more than once", cp.Selector)
+		}
+		if cp.Default {
+			if defaultSelector != "" {
+				return nil, errors.Errorf("connection points %s and %s are both default", defaultSelector, cp.Selector)
+			}
+			defaultSelector = cp.Selector
+		}
+		l2Point, err := cp.toL2ConnectionPoint()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid connection point %s", cp.Selector)
+		}
+		l2Connections[cp.Selector] = l2Point
+	}
+	return l2Connections, nil
+}
+
+func (cp *ConnectionPoint) toL2ConnectionPoint() (*ovsutil.L2ConnectionPoint, error) {
+	if cp.Bridge == "" {
+		return nil, errors.New("no bridge set")
+	}
+	if cp.MTU > maxMTU {
+		return nil, errors.Errorf("invalid mtu %d", cp.MTU)
+	}
+	l2Point := &ovsutil.L2ConnectionPoint{Bridge: cp.Bridge, MTU: cp.MTU, Labels: cp.Labels, Default: cp.Default}
+	for _, r := range cp.VlanRanges {
+		if r == nil || r.Min > r.Max || r.Max > maxVlanID {
+			return nil, errors.Errorf("invalid vlan range %+v", r)
+		}
+		l2Point.AllowedVlans = append(l2Point.AllowedVlans, ovsutil.VlanRange{Min: r.Min, Max: r.Max})
+	}
+	for _, vlanID := range cp.ReservedVlans {
+		if vlanID > maxVlanID {
+			return nil, errors.Errorf("invalid reserved vlan %d", vlanID)
+		}
+	}
+	l2Point.ReservedVlans = cp.ReservedVlans
+	switch {
+	case cp.Bond != nil:
+		if cp.Bond.Name == "" {
+			return nil, errors.New("no bond name set")
+		}
+		if len(cp.Interfaces) < 2 {
+			return nil, errors.Errorf("bond %s requires at least two interfaces", cp.Bond.Name)
+		}
+		l2Point.Interface = cp.Bond.Name
+		l2Point.Bond = &ovsutil.BondConfig{
+			Members:  cp.Interfaces,
+			Mode:     cp.Bond.Mode,
+			LACP:     cp.Bond.LACP,
+			LACPTime: cp.Bond.LACPTime,
+		}
+	case len(cp.Interfaces) > 1:
+		return nil, errors.New("multiple interfaces require a bond")
+	case len(cp.Interfaces) == 1:
+		l2Point.Interface = cp.Interfaces[0]
+	}
+	return l2Point, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package l2config
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/networkservicemesh/sdk/pkg/tools/fs"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	ovsutil "github.com/networkservicemesh/sdk-ovs/pkg/tools/utils"
+)
+
+// ConfigureFunc configures the datapath of an added or changed l2 connection point
+type ConfigureFunc func(ctx context.Context, l2Point *ovsutil.L2ConnectionPoint) error
+
+// Watch applies the changes of the config file to l2Connections until ctx is done. The connection points
+// added or changed in the file are configured before they are made available, the removed ones are no
+// longer available for new connections while the established connections keep their datapath. An invalid
+// config is logged and ignored. initial is the content of the config file already applied.
+func Watch(ctx context.Context, configFile string, initial map[string]*ovsutil.L2ConnectionPoint,
+	l2Connections *genericsync.Map[string, *ovsutil.L2ConnectionPoint], configure ConfigureFunc) {
+	logger := log.FromContext(ctx).WithField("l2config", "Watch")
+	current := initial
+	go func() {
+		for data := range fs.WatchFile(ctx, configFile) {
+			if data == nil {
+				logger.Warnf("config file %s is removed, keeping the current connection points", configFile)
+				continue
+			}
+			updated, err := Parse(data)
+			if err != nil {
+				logger.Errorf("invalid config file %s, keeping the current connection points: %v", configFile, err)
+				continue
+			}
+			current = apply(ctx, logger, current, updated, l2Connections, configure)
+		}
+	}()
+}
+
+// apply returns the connection points actually applied, the ones failed to configure are left out
+func apply(ctx context.Context, logger log.Logger, current, updated map[string]*ovsutil.L2ConnectionPoint,
+	l2Connections *genericsync.Map[string, *ovsutil.L2ConnectionPoint], configure ConfigureFunc) map[string]*ovsutil.L2ConnectionPoint {
+	for selector := range current {
+		if _, ok := updated[selector]; !ok {
+			l2Connections.Delete(selector)
+			logger.Infof("removed l2 connection point %s", selector)
+		}
+	}
+	applied := make(map[string]*ovsutil.L2ConnectionPoint)
+	for selector, l2Point := range updated {
+		if reflect.DeepEqual(current[selector], l2Point) {
+			applied[selector] = current[selector]
+			continue
+		}
+		if err := configure(ctx, l2Point); err != nil {
+			logger.Errorf("failed to configure l2 connection point %s: %v", selector, err)
+			l2Connections.Delete(selector)
+			continue
+		}
+		l2Connections.Store(selector, l2Point)
+		applied[selector] = l2Point
+		logger.Infof("applied l2 connection point %s: %+v", selector, *l2Point)
+	}
+	return applied
+}
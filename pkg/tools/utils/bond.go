@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// BondConfig contains the ovs bond config of an L2ConnectionPoint
+type BondConfig struct {
+	// Members are the uplink interfaces of the bond, at least two are required
+	Members []string
+	// Mode is the ovs bond_mode, e.g. active-backup, balance-slb or balance-tcp
+	Mode string
+	// LACP is the lacp mode of the bond: active, passive or off
+	LACP string
+	// LACPTime is the lacp-time of the bond: fast or slow
+	LACPTime string
+}
+
+// BondStatus contains the runtime status of an ovs bond
+type BondStatus struct {
+	Mode       string
+	LACPStatus string
+	Members    []BondMemberStatus
+}
+
+// BondMemberStatus contains the runtime status of an ovs bond member
+type BondMemberStatus struct {
+	Name    string
+	Enabled bool
+	Active  bool
+}
+
+// configureL2Bond creates the bond port of the l2 connection point from its member interfaces and moves
+// the addresses, routes and policy rules of the members to the bridge
+func configureL2Bond(ctx context.Context, cp *L2ConnectionPoint, uplinkStateDir string) error {
+	if len(cp.Bond.Members) < 2 {
+		return errors.Errorf("bond %s requires at least two members", cp.Interface)
+	}
+	err := takeOverUplinks(ctx, uplinkStateDir, cp.Bond.Members, cp.Interface, cp.Bridge, func() error {
+		args := append([]string{"--", "--may-exist", "add-bond", cp.Bridge, cp.Interface}, cp.Bond.Members...)
+		stdout, stderr, err := util.RunOVSVsctl(args...)
+		if err != nil {
+			log.FromContext(ctx).Errorf("Failed to add l2 egress bond %s to %s, stdout: %q, stderr: %q,"+
+				" error: %v", cp.Interface, cp.Bridge, stdout, stderr, err)
+			return errors.Wrap(err, "failed to run command via ovs-vsctl")
+		}
+		// the settings are applied separately as --may-exist leaves an existing bond untouched
+		if settings := cp.Bond.columns(); len(settings) > 0 {
+			stdout, stderr, err = util.RunOVSVsctl(append([]string{"set", "port", cp.Interface}, settings...)...)
+			if err != nil {
+				log.FromContext(ctx).Errorf("Failed to configure l2 egress bond %s, stdout: %q, stderr: %q,"+
+					" error: %v", cp.Interface, stdout, stderr, err)
+				return errors.Wrap(err, "failed to run command via ovs-vsctl")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if status, statusErr := GetBondStatus(cp.Interface); statusErr == nil {
+		log.FromContext(ctx).Infof("l2 egress bond %s status: %+v", cp.Interface, *status)
+	}
+	return nil
+}
+
+func (b *BondConfig) columns() []string {
+	var columns []string
+	if b.Mode != "" {
+		columns = append(columns, "bond_mode="+b.Mode)
+	}
+	if b.LACP != "" {
+		columns = append(columns, "lacp="+b.LACP)
+	}
+	if b.LACPTime != "" {
+		columns = append(columns, "other_config:lacp-time="+b.LACPTime)
+	}
+	return columns
+}
+
+// GetBondStatus returns the runtime status of the bond and its members from ovs-appctl bond/show
+func GetBondStatus(bondName string) (*BondStatus, error) {
+	stdout, stderr, err := util.RunOVSAppctl("bond/show", bondName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to show bond %s, stderr: %q", bondName, stderr)
+	}
+	status := &BondStatus{}
+	var member *BondMemberStatus
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "bond_mode:"):
+			status.Mode = strings.TrimSpace(strings.TrimPrefix(line, "bond_mode:"))
+		case strings.HasPrefix(line, "lacp_status:"):
+			status.LACPStatus = strings.TrimSpace(strings.TrimPrefix(line, "lacp_status:"))
+		// older ovs versions name the members slaves
+		case strings.HasPrefix(line, "member ") || strings.HasPrefix(line, "slave "):
+			var name, state string
+			if _, scanErr := fmt.Sscanf(line[strings.Index(line, " ")+1:], "%s %s", &name, &state); scanErr != nil {
+				continue
+			}
+			status.Members = append(status.Members, BondMemberStatus{
+				Name:    strings.TrimSuffix(name, ":"),
+				Enabled: state == "enabled",
+			})
+			member = &status.Members[len(status.Members)-1]
+		case line == "active member" || line == "active slave":
+			if member != nil {
+				member.Active = true
+			}
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FlowExportIPFIX - IPFIX flow export protocol
+	FlowExportIPFIX = "ipfix"
+	// FlowExportSFlow - sFlow flow export protocol
+	FlowExportSFlow = "sflow"
+	// FlowSampleCollectorSetID - ID of the flow sample collector set receiving the per connection samples
+	FlowSampleCollectorSetID = 1
+	maxSampleProbability     = 65535
+)
+
+// FlowExportConfig contains the flow export settings of a bridge
+type FlowExportConfig struct {
+	// Protocol is FlowExportIPFIX or FlowExportSFlow
+	Protocol string
+	// Targets are the "ip:port" addresses of the collectors
+	Targets []string
+	// Sampling is the bridge wide sampling rate, 1 out of Sampling packets is sampled, none when 0
+	Sampling uint32
+	// FlowSampling is the sampling rate of the per connection IPFIX samples, none when 0
+	FlowSampling uint32
+	// ObsDomainID is the IPFIX observation domain ID
+	ObsDomainID uint32
+	// Agent is the interface whose address is the sFlow agent address
+	Agent string
+	// Polling is the sFlow counter polling interval in seconds
+	Polling uint32
+}
+
+// ConfigureFlowExport configures the IPFIX or sFlow export of the bridge, the previous flow export
+// configuration of the bridge is removed first. Nothing is exported when config is nil.
+func ConfigureFlowExport(bridgeName string, config *FlowExportConfig) error {
+	args, err := clearFlowExportArgs(bridgeName)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		exportArgs, argsErr := config.args(bridgeName)
+		if argsErr != nil {
+			return argsErr
+		}
+		args = append(args, exportArgs...)
+	}
+	stdout, stderr, err := util.RunOVSVsctl(args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to configure flow export on %s, stdout: %q, stderr: %q", bridgeName, stdout, stderr)
+	}
+	return nil
+}
+
+// clearFlowExportArgs returns the ovs-vsctl commands removing the flow export configuration of the bridge
+func clearFlowExportArgs(bridgeName string) ([]string, error) {
+	bridgeUUID, stderr, err := util.RunOVSVsctl("get", "bridge", bridgeName, "_uuid")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get bridge %s, stderr: %q", bridgeName, stderr)
+	}
+	stdout, stderr, err := util.RunOVSVsctl("--bare", "--columns=_uuid", "find", "flow_sample_collector_set", "bridge="+bridgeUUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find flow sample collector sets of %s, stderr: %q", bridgeName, stderr)
+	}
+	args := []string{"--", "clear", "bridge", bridgeName, "ipfix", "sflow"}
+	for _, collectorSet := range strings.Fields(stdout) {
+		args = append(args, "--", "destroy", "flow_sample_collector_set", collectorSet)
+	}
+	return args, nil
+}
+
+func (c *FlowExportConfig) args(bridgeName string) ([]string, error) {
+	if len(c.Targets) == 0 {
+		return nil, errors.New("no flow export target set")
+	}
+	quoted := make([]string, 0, len(c.Targets))
+	for _, target := range c.Targets {
+		quoted = append(quoted, strconv.Quote(target))
+	}
+	targets := "targets=" + strings.Join(quoted, ",")
+
+	var args []string
+	switch c.Protocol {
+	case FlowExportIPFIX:
+		if c.Sampling > 0 {
+			args = append(args, "--", "set", "bridge", bridgeName, "ipfix=@ipfix",
+				"--", "--id=@ipfix", "create", "ipfix", targets, fmt.Sprintf("sampling=%d", c.Sampling),
+				fmt.Sprintf("obs_domain_id=%d", c.ObsDomainID))
+		}
+		if c.FlowSampling > 0 {
+			args = append(args, "--", "--id=@bridge", "get", "bridge", bridgeName,
+				"--", "--id=@flowipfix", "create", "ipfix", targets,
+				"--", "create", "flow_sample_collector_set", fmt.Sprintf("id=%d", FlowSampleCollectorSetID),
+				"bridge=@bridge", "ipfix=@flowipfix")
+		}
+	case FlowExportSFlow:
+		if c.FlowSampling > 0 {
+			return nil, errors.New("per connection flow sampling requires ipfix")
+		}
+		args = append(args, "--", "set", "bridge", bridgeName, "sflow=@sflow",
+			"--", "--id=@sflow", "create", "sflow", targets, fmt.Sprintf("sampling=%d", c.Sampling))
+		if c.Polling > 0 {
+			args = append(args, fmt.Sprintf("polling=%d", c.Polling))
+		}
+		if c.Agent != "" {
+			args = append(args, "agent="+c.Agent)
+		}
+	default:
+		return nil, errors.Errorf("unknown flow export protocol %q", c.Protocol)
+	}
+	return args, nil
+}
+
+// ObservationPointID returns the IPFIX observation point ID of the connection samples, it is derived from the
+// connection ID. The ID of a colliding connection is replaced, so the collectors join the records back to the
+// connections by the IDs published in the path segment metrics of the connections.
+func ObservationPointID(connID string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(connID))
+	return h.Sum32()
+}
+
+// FlowSampleAction returns the openflow action sampling 1 out of sampling packets to the flow sample collector set
+func FlowSampleAction(sampling, obsDomainID, obsPointID uint32) string {
+	probability := uint32(maxSampleProbability)
+	if sampling > 1 {
+		probability = maxSampleProbability / sampling
+	}
+	if probability == 0 {
+		probability = 1
+	}
+	return fmt.Sprintf("sample(probability=%d,collector_set_id=%d,obs_domain_id=%d,obs_point_id=%d)",
+		probability, FlowSampleCollectorSetID, obsDomainID, obsPointID)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"encoding/csv"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// GetInterfaceLinkStates returns the ovs interfaces by name and whether they are up. An interface is up when it got
+// an openflow port and its link_state is up, which covers the ports without a kernel netdev, e.g. the DPDK and the
+// vhost-user ports.
+func GetInterfaceLinkStates() (map[string]bool, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--no-headings", "--format=csv", "--data=bare",
+		"--columns=name,ofport,link_state", "list", "interface")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list interfaces, stderr: %q", stderr)
+	}
+	return parseInterfaceLinkStates(stdout)
+}
+
+func parseInterfaceLinkStates(stdout string) (map[string]bool, error) {
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse interfaces %q", stdout)
+	}
+	states := make(map[string]bool)
+	for _, record := range records {
+		if len(record) != 3 {
+			continue
+		}
+		ofport := strings.TrimSpace(record[1])
+		states[record[0]] = ofport != "" && ofport != "-1" && strings.TrimSpace(record[2]) == "up"
+	}
+	return states, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterfaceLinkStates(t *testing.T) {
+	states, err := parseInterfaceLinkStates(`br-nsm,65534,down
+nsm-vhu-link,1,up
+vhu-1234,3,up
+vhu-5678,2,down
+dpdk0,-1,
+"nsc-a,b",4,up
+`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{
+		"br-nsm":       false,
+		"nsm-vhu-link": true,
+		"vhu-1234":     true,
+		"vhu-5678":     false,
+		"dpdk0":        false,
+		"nsc-a,b":      true,
+	}, states)
+
+	_, err = parseInterfaceLinkStates(`vhu-1234,"3,up`)
+	require.Error(t, err)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+)
+
+// ViaLabel is the connection label selecting the l2 connection point by its key
+const ViaLabel = "via"
+
+// NewL2ConnectionRegistry returns a registry of the given l2 connection points
+func NewL2ConnectionRegistry(l2Connections map[string]*L2ConnectionPoint) *genericsync.Map[string, *L2ConnectionPoint] {
+	registry := &genericsync.Map[string, *L2ConnectionPoint]{}
+	for selector, l2Point := range l2Connections {
+		registry.Store(selector, l2Point)
+	}
+	return registry
+}
+
+// SelectL2ConnectionPoint returns the l2 connection point for the connection labels and its key. The connection
+// point keyed by the "via" label is selected if the label is set, otherwise the one with the most Labels all
+// matching the connection labels, then the Default one. It returns error when no connection point matches.
+func SelectL2ConnectionPoint(l2Connections *genericsync.Map[string, *L2ConnectionPoint],
+	labels map[string]string) (string, *L2ConnectionPoint, error) {
+	if via, ok := labels[ViaLabel]; ok {
+		l2Point, found := l2Connections.Load(via)
+		if !found {
+			return "", nil, errors.Errorf("no l2 connection point %s", via)
+		}
+		return via, l2Point, nil
+	}
+	var selected, defaultSelector string
+	var selectedPoint, defaultPoint *L2ConnectionPoint
+	l2Connections.Range(func(selector string, l2Point *L2ConnectionPoint) bool {
+		if l2Point.Default && (defaultPoint == nil || selector < defaultSelector) {
+			defaultSelector, defaultPoint = selector, l2Point
+		}
+		if len(l2Point.Labels) == 0 || !l2Point.matches(labels) {
+			return true
+		}
+		// the most specific connection point wins, the key breaks the tie to keep the selection stable
+		if selectedPoint == nil || len(l2Point.Labels) > len(selectedPoint.Labels) ||
+			(len(l2Point.Labels) == len(selectedPoint.Labels) && selector < selected) {
+			selected, selectedPoint = selector, l2Point
+		}
+		return true
+	})
+	if selectedPoint != nil {
+		return selected, selectedPoint, nil
+	}
+	if defaultPoint != nil {
+		return defaultSelector, defaultPoint, nil
+	}
+	return "", nil, errors.Errorf("no l2 connection point matches the connection labels %v", labels)
+}
+
+func (cp *L2ConnectionPoint) matches(labels map[string]string) bool {
+	for key, value := range cp.Labels {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectL2ConnectionPoint(t *testing.T) {
+	l2Connections := NewL2ConnectionRegistry(map[string]*L2ConnectionPoint{
+		"default-b": {Bridge: "br-default-b", Default: true},
+		"default-a": {Bridge: "br-default-a", Default: true},
+		"red":       {Bridge: "br-red", Labels: map[string]string{"color": "red"}},
+		"red-fast":  {Bridge: "br-red-fast", Labels: map[string]string{"color": "red", "speed": "fast"}},
+		"blue-a":    {Bridge: "br-blue-a", Labels: map[string]string{"color": "blue"}},
+		"blue-b":    {Bridge: "br-blue-b", Labels: map[string]string{"color": "blue"}},
+	})
+	for _, sample := range []struct {
+		name             string
+		l2Connections    map[string]*L2ConnectionPoint
+		labels           map[string]string
+		expectedSelector string
+		isErr            bool
+	}{
+		{
+			name:             "via label",
+			labels:           map[string]string{ViaLabel: "red", "color": "blue"},
+			expectedSelector: "red",
+		},
+		{
+			name:   "via label of unknown connection point",
+			labels: map[string]string{ViaLabel: "green"},
+			isErr:  true,
+		},
+		{
+			name:             "labels match",
+			labels:           map[string]string{"color": "red", "app": "nsc"},
+			expectedSelector: "red",
+		},
+		{
+			name:             "most specific labels match",
+			labels:           map[string]string{"color": "red", "speed": "fast"},
+			expectedSelector: "red-fast",
+		},
+		{
+			name:             "tie broken by the key",
+			labels:           map[string]string{"color": "blue"},
+			expectedSelector: "blue-a",
+		},
+		{
+			name:             "default when no labels match",
+			labels:           map[string]string{"color": "green"},
+			expectedSelector: "default-a",
+		},
+		{
+			name:             "default without labels",
+			expectedSelector: "default-a",
+		},
+		{
+			name: "no match without default",
+			l2Connections: map[string]*L2ConnectionPoint{
+				"red": {Bridge: "br-red", Labels: map[string]string{"color": "red"}},
+				// a connection point without labels is selected only by the via label
+				"plain": {Bridge: "br-plain"},
+			},
+			labels: map[string]string{"color": "green"},
+			isErr:  true,
+		},
+	} {
+		t.Run(sample.name, func(t *testing.T) {
+			registry := l2Connections
+			if sample.l2Connections != nil {
+				registry = NewL2ConnectionRegistry(sample.l2Connections)
+			}
+			selector, l2Point, err := SelectL2ConnectionPoint(registry, sample.labels)
+			if sample.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, sample.expectedSelector, selector)
+			expected, _ := registry.Load(sample.expectedSelector)
+			require.Same(t, expected, l2Point)
+		})
+	}
+}
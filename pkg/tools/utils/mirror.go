@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strconv"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// AddMirror creates the ovs mirror mirrorName copying the packets sent and received on portName to outputPort
+func AddMirror(bridgeName, mirrorName, portName, outputPort string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--", "--id=@src", "get", "port", portName,
+		"--", "--id=@out", "get", "port", outputPort,
+		"--", "--id=@m", "create", "mirror", "name="+mirrorName, "select-src-port=@src", "select-dst-port=@src", "output-port=@out",
+		"--", "add", "bridge", bridgeName, "mirrors", "@m")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create mirror %s of port %s to %s on %s, stdout: %q, stderr: %q",
+			mirrorName, portName, outputPort, bridgeName, stdout, stderr)
+	}
+	return nil
+}
+
+// DeleteMirror deletes the ovs mirror mirrorName from the bridge
+func DeleteMirror(bridgeName, mirrorName string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--", "--id=@m", "get", "mirror", mirrorName,
+		"--", "remove", "bridge", bridgeName, "mirrors", "@m")
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete mirror %s on %s, stdout: %q, stderr: %q", mirrorName, bridgeName, stdout, stderr)
+	}
+	return nil
+}
+
+// AddMirrorPort adds the existing interface portName, e.g. one end of a veth pair, to the bridge as mirror output
+func AddMirrorPort(bridgeName, portName string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", bridgeName, portName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add mirror port %s to %s, stdout: %q, stderr: %q", portName, bridgeName, stdout, stderr)
+	}
+	return nil
+}
+
+// AddERSPANPort adds an ERSPAN version 1 tunnel port towards remoteIP to the bridge as mirror output
+func AddERSPANPort(bridgeName, portName, remoteIP string, key uint32) error {
+	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", bridgeName, portName,
+		"--", "set", "interface", portName, "type=erspan", "options:remote_ip="+remoteIP,
+		"options:key="+strconv.FormatUint(uint64(key), 10), "options:erspan_ver=1", "options:erspan_idx=1")
+	if err != nil {
+		return errors.Wrapf(err, "failed to add erspan port %s to %s, stdout: %q, stderr: %q", portName, bridgeName, stdout, stderr)
+	}
+	return nil
+}
+
+// DeletePort deletes the port from the bridge, it is not an error if the port does not exist
+func DeletePort(bridgeName, portName string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--", "--if-exists", "del-port", bridgeName, portName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete port %s from %s, stdout: %q, stderr: %q", portName, bridgeName, stdout, stderr)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// Datapath flow offload status of an ovs port
+const (
+	// OffloadStatusDisabled - hw-offload is not enabled in ovs
+	OffloadStatusDisabled = "disabled"
+	// OffloadStatusUnknown - no datapath flows are present for the port yet
+	OffloadStatusUnknown = "unknown"
+	// OffloadStatusOffloaded - all the datapath flows of the port are offloaded to the NIC
+	OffloadStatusOffloaded = "offloaded"
+	// OffloadStatusPartial - only some of the datapath flows of the port are offloaded to the NIC
+	OffloadStatusPartial = "partial"
+	// OffloadStatusNotOffloaded - none of the datapath flows of the port are offloaded to the NIC
+	OffloadStatusNotOffloaded = "not-offloaded"
+)
+
+// IsHwOffloadEnabled checks whether hw-offload is enabled in ovs other_config
+func IsHwOffloadEnabled() (bool, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "other_config:hw-offload")
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get hw-offload config, stdout: %q, stderr: %q", stdout, stderr)
+	}
+	return strings.Trim(stdout, "\"") == "true", nil
+}
+
+// GetOffloadStatus inspects the datapath flows received on or sent to the given port and reports
+// whether they got offloaded to the NIC eswitch
+func GetOffloadStatus(portName string) (string, error) {
+	offloaded, err := countDatapathFlows(portName, "offloaded")
+	if err != nil {
+		return OffloadStatusUnknown, err
+	}
+	notOffloaded, err := countDatapathFlows(portName, "ovs")
+	if err != nil {
+		return OffloadStatusUnknown, err
+	}
+	switch {
+	case offloaded == 0 && notOffloaded == 0:
+		return OffloadStatusUnknown, nil
+	case notOffloaded == 0:
+		return OffloadStatusOffloaded, nil
+	case offloaded == 0:
+		return OffloadStatusNotOffloaded, nil
+	default:
+		return OffloadStatusPartial, nil
+	}
+}
+
+func countDatapathFlows(portName, flowType string) (int, error) {
+	stdout, stderr, err := util.RunOVSAppctl("dpctl/dump-flows", "--names", "type="+flowType)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to dump %s datapath flows, stdout: %q, stderr: %q", flowType, stdout, stderr)
+	}
+	inPort := "in_port(" + portName + ")"
+	var count int
+	for _, flow := range strings.Split(stdout, "\n") {
+		if strings.Contains(flow, inPort) || outputsTo(flow, portName) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// outputsTo checks if the port is one of the outputs in the actions of the datapath flow
+func outputsTo(flow, portName string) bool {
+	idx := strings.Index(flow, "actions:")
+	if idx < 0 {
+		return false
+	}
+	for _, action := range strings.Split(flow[idx+len("actions:"):], ",") {
+		if action == portName {
+			return true
+		}
+	}
+	return false
+}
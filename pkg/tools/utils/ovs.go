@@ -24,10 +24,10 @@ package utils
 import (
 	"context"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/vishvananda/netlink"
 
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -38,6 +38,48 @@ import (
 type L2ConnectionPoint struct {
 	Interface string
 	Bridge    string
+	// Bond is set when Interface is the name of an ovs bond port built from member interfaces
+	Bond *BondConfig
+	// AllowedVlans restricts the VLAN IDs broken out through the connection point, any VLAN ID when empty. The
+	// VLAN IDs are handed out from these ranges to the connections the endpoint gives no VLAN ID.
+	AllowedVlans []VlanRange
+	// ReservedVlans are VLAN IDs never broken out through the connection point
+	ReservedVlans []uint32
+	// MTU overrides the MTU of the uplink interface when set
+	MTU uint32
+	// Labels select the connection point for the connections having all of them, see SelectL2ConnectionPoint
+	Labels map[string]string
+	// Default selects the connection point for the connections no other connection point matches
+	Default bool
+}
+
+// VlanRange is an inclusive range of VLAN IDs
+type VlanRange struct {
+	Min uint32
+	Max uint32
+}
+
+// Contains returns true if the VLAN ID is within the range
+func (r *VlanRange) Contains(vlanID uint32) bool {
+	return vlanID >= r.Min && vlanID <= r.Max
+}
+
+// IsVlanAllowed returns true if the VLAN ID can be broken out through the connection point
+func (cp *L2ConnectionPoint) IsVlanAllowed(vlanID uint32) bool {
+	for _, reserved := range cp.ReservedVlans {
+		if vlanID == reserved {
+			return false
+		}
+	}
+	if len(cp.AllowedVlans) == 0 {
+		return true
+	}
+	for idx := range cp.AllowedVlans {
+		if cp.AllowedVlans[idx].Contains(vlanID) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetInterfaceOfPort get Port number from Interface name in OVS
@@ -67,27 +109,52 @@ func GetInterfaceOfPort(logger log.Logger, interfaceName string) (int, error) {
 	return portNo, nil
 }
 
+var initOvsExecOnce sync.Once
+
+// InitOvsExec initializes the ovs utility wrapper, it must run before any ovs command. It is run once, the next
+// calls do nothing.
+func InitOvsExec(ctx context.Context) {
+	initOvsExecOnce.Do(func() {
+		if err := util.SetExec(kexec.New()); err != nil {
+			log.FromContext(ctx).Warnf("failed to initialize ovs exec helper: %v", err)
+		}
+	})
+}
+
+// ConfigureOption is an option pattern for ConfigureOvS
+type ConfigureOption func(o *configureOptions)
+
+// WithUplinkStateDir sets the directory where the original configuration of the uplinks taken over by the l2
+// bridges is persisted to be restored by RestoreUplinks, it is not persisted by default
+func WithUplinkStateDir(stateDir string) ConfigureOption {
+	return func(o *configureOptions) {
+		o.uplinkStateDir = stateDir
+	}
+}
+
+// WithFlowExport sets the IPFIX or sFlow export of the integration bridge, nothing is exported by default
+func WithFlowExport(config *FlowExportConfig) ConfigureOption {
+	return func(o *configureOptions) {
+		o.flowExport = config
+	}
+}
+
+type configureOptions struct {
+	uplinkStateDir string
+	flowExport     *FlowExportConfig
+}
+
 // ConfigureOvS creates ovs bridge and make it as an integration bridge
-func ConfigureOvS(ctx context.Context, l2Connections map[string]*L2ConnectionPoint, bridgeName string) error {
-	// Initialize the ovs utility wrapper.
-	exec := kexec.New()
-	if err := util.SetExec(exec); err != nil {
-		log.FromContext(ctx).Warnf("failed to initialize ovs exec helper: %v", err)
+func ConfigureOvS(ctx context.Context, l2Connections map[string]*L2ConnectionPoint, bridgeName string,
+	options ...ConfigureOption) error {
+	opts := &configureOptions{}
+	for _, opt := range options {
+		opt(opts)
 	}
+	InitOvsExec(ctx)
 
 	for _, cp := range l2Connections {
-		if cp.Bridge != "" {
-			// Create ovs bridge for l2 egress point
-			stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-br", cp.Bridge)
-			if err != nil {
-				log.FromContext(ctx).Warnf("Failed to add bridge %s, stdout: %q, stderr: %q, error: %v", bridgeName, stdout, stderr, err)
-			}
-		}
-		if cp.Interface == "" {
-			continue
-		}
-		err := configureL2Interface(ctx, cp)
-		if err != nil {
+		if err := ConfigureL2ConnectionPoint(ctx, cp, opts.uplinkStateDir); err != nil {
 			return err
 		}
 	}
@@ -105,56 +172,72 @@ func ConfigureOvS(ctx context.Context, l2Connections map[string]*L2ConnectionPoi
 			"stdout: %q, stderr: %q, error: %v", bridgeName, stdout, stderr, err)
 	}
 
-	return nil
-}
-
-func configureL2Interface(ctx context.Context, cp *L2ConnectionPoint) error {
-	link, err := netlink.LinkByName(cp.Interface)
+	// The group IDs are allocated from 1 again, clean the groups left by a previous run
+	stdout, stderr, err = util.RunOVSOfctl("del-groups", "-OOpenflow13", bridgeName)
 	if err != nil {
-		return errors.Wrapf(err, "failed to find link %s", cp.Interface)
+		log.FromContext(ctx).Warnf("Failed to cleanup groups on %s "+
+			"stdout: %q, stderr: %q, error: %v", bridgeName, stdout, stderr, err)
 	}
-	// TODO: find a way to flush the ip's (if exists) in one go.
-	v4addr, err := netlink.AddrList(link, netlink.FAMILY_V4)
+
+	// The meter IDs are allocated from 1 again, clean the meters left by a previous run
+	stdout, stderr, err = util.RunOVSOfctl("del-meters", "-OOpenflow13", bridgeName)
 	if err != nil {
-		return errors.Wrap(err, "failed to get a list of IP addresses")
+		log.FromContext(ctx).Warnf("Failed to cleanup meters on %s "+
+			"stdout: %q, stderr: %q, error: %v", bridgeName, stdout, stderr, err)
 	}
-	for idx := range v4addr {
-		err = netlink.AddrDel(link, &v4addr[idx])
-		if err != nil {
-			return errors.Wrapf(err, "failed to delete IP address from link device")
-		}
+
+	if err = ConfigurePipeline(bridgeName); err != nil {
+		log.FromContext(ctx).Errorf("Failed to configure the pipeline of %s: %v", bridgeName, err)
+		return err
 	}
-	v6addr, err := netlink.AddrList(link, netlink.FAMILY_V6)
-	if err != nil {
-		return errors.Wrap(err, "failed to get a list of IP addresses")
+
+	// the flow export of a previous run is removed when none is set
+	if err = ConfigureFlowExport(bridgeName, opts.flowExport); err != nil {
+		log.FromContext(ctx).Errorf("Failed to configure the flow export of %s: %v", bridgeName, err)
+		return err
 	}
-	for idx := range v6addr {
-		err = netlink.AddrDel(link, &v6addr[idx])
+
+	return nil
+}
+
+// ConfigureL2ConnectionPoint creates the ovs bridge of the l2 egress point and attaches its uplink interface or bond,
+// the addresses, routes and policy rules of the uplink are moved to the bridge
+func ConfigureL2ConnectionPoint(ctx context.Context, cp *L2ConnectionPoint, uplinkStateDir string) error {
+	if cp.Bridge != "" {
+		// Create ovs bridge for l2 egress point
+		stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-br", cp.Bridge)
 		if err != nil {
-			return errors.Wrapf(err, "failed to delete IP address from link device")
+			log.FromContext(ctx).Warnf("Failed to add bridge %s, stdout: %q, stderr: %q, error: %v", cp.Bridge, stdout, stderr, err)
 		}
 	}
-	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", cp.Bridge, cp.Interface)
-	if err != nil {
-		log.FromContext(ctx).Errorf("Failed to add l2 egress port %s to %s, stdout: %q, stderr: %q,"+
-			" error: %v", cp.Interface, cp.Bridge, stdout, stderr, err)
-		return errors.Wrap(err, "failed to run command via ovs-vsctl")
+	if cp.Interface == "" {
+		return nil
 	}
-	link, err = netlink.LinkByName(cp.Bridge)
-	if err != nil {
-		return errors.Wrapf(err, "failed to find link %s", cp.Bridge)
+	if cp.Bond != nil {
+		return configureL2Bond(ctx, cp, uplinkStateDir)
 	}
-	for idx := range v4addr {
-		err = netlink.AddrAdd(link, &v4addr[idx])
-		if err != nil {
-			return errors.Wrapf(err, "failed to add IP address from link device")
-		}
+	return configureL2Interface(ctx, cp, uplinkStateDir)
+}
+
+// ConfigureDatapathType sets the datapath type of the given ovs bridge, e.g. "netdev" for OVS-DPDK userspace bridges
+func ConfigureDatapathType(ctx context.Context, bridgeName, datapathType string) error {
+	stdout, stderr, err := util.RunOVSVsctl("set", "bridge", bridgeName, "datapath_type="+datapathType)
+	if err != nil {
+		log.FromContext(ctx).Errorf("Failed to set datapath type %s on %s, stdout: %q, stderr: %q,"+
+			" error: %v", datapathType, bridgeName, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to set datapath type %s on %s", datapathType, bridgeName)
 	}
-	for idx := range v6addr {
-		err = netlink.AddrAdd(link, &v6addr[idx])
+	return nil
+}
+
+func configureL2Interface(ctx context.Context, cp *L2ConnectionPoint, uplinkStateDir string) error {
+	return takeOverUplinks(ctx, uplinkStateDir, []string{cp.Interface}, cp.Interface, cp.Bridge, func() error {
+		stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", cp.Bridge, cp.Interface)
 		if err != nil {
-			return errors.Wrapf(err, "failed to add IP address from link device")
+			log.FromContext(ctx).Errorf("Failed to add l2 egress port %s to %s, stdout: %q, stderr: %q,"+
+				" error: %v", cp.Interface, cp.Bridge, stdout, stderr, err)
+			return errors.Wrap(err, "failed to run command via ovs-vsctl")
 		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// GetPatchPortName returns the name of the patch port on fromBridge peered with toBridge
+func GetPatchPortName(fromBridge, toBridge string) string {
+	return fromBridge + "-to-" + toBridge
+}
+
+// ConfigurePatchPorts links the integration bridge to the bridge of every l2 connection point with a
+// patch port pair, so that VLAN breakout can be done with flows without moving ports between bridges
+func ConfigurePatchPorts(ctx context.Context, l2Connections map[string]*L2ConnectionPoint, bridgeName string) error {
+	for _, cp := range l2Connections {
+		if err := ConfigurePatchPort(ctx, cp, bridgeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigurePatchPort links the integration bridge to the bridge of the l2 connection point with a patch port pair
+func ConfigurePatchPort(ctx context.Context, cp *L2ConnectionPoint, bridgeName string) error {
+	if cp.Bridge == "" {
+		return nil
+	}
+	if err := addPatchPort(ctx, bridgeName, cp.Bridge); err != nil {
+		return err
+	}
+	return addPatchPort(ctx, cp.Bridge, bridgeName)
+}
+
+func addPatchPort(ctx context.Context, fromBridge, toBridge string) error {
+	portName := GetPatchPortName(fromBridge, toBridge)
+	stdout, stderr, err := util.RunOVSVsctl("--", "--may-exist", "add-port", fromBridge, portName,
+		"--", "set", "interface", portName, "type=patch", "options:peer="+GetPatchPortName(toBridge, fromBridge))
+	if err != nil {
+		log.FromContext(ctx).Errorf("Failed to add patch port %s to %s, stdout: %q, stderr: %q,"+
+			" error: %v", portName, fromBridge, stdout, stderr, err)
+		return errors.Wrapf(err, "failed to add patch port %s to %s", portName, fromBridge)
+	}
+	return nil
+}
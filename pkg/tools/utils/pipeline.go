@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// openflow tables of the integration bridge pipeline. A packet is classified in ClassificationTable (and
+// QinQTable for the customer VLAN), goes through the SecurityTable and the ConntrackTable / ACLTable stages,
+// is encapsulated or decapsulated in TunnelTable which also selects the output port, and leaves in OutputTable.
+// The packets of the multipoint l2 domains are forwarded by their destination MAC in L2LearningTable and
+// L2ForwardingTable instead, or flooded to the other members of the domain.
+const (
+	// ClassificationTable - matches the ingress port of the packets of a cross connect
+	ClassificationTable = 0
+	// SecurityTable - anti-spoofing stage, passes the packets to ConntrackTable by default
+	SecurityTable = 10
+	// ConntrackTable - sends the ip packets of the connections having an acl to conntrack, passes the packets
+	// to TunnelTable by default
+	ConntrackTable = 20
+	// ACLTable - stateful acl stage of the tracked packets, drops by default
+	ACLTable = 21
+	// TunnelTable - pushes and pops VLANs, sets the tunnel ID and selects the output port, drops by default
+	TunnelTable = 30
+	// L2LearningTable - the MAC addresses learned in the multipoint l2 domains, the learned flows load the domain
+	// member the address was seen on
+	L2LearningTable = 31
+	// L2ForwardingTable - forwards the packets of a multipoint l2 domain to the learned member or floods them to
+	// the other members, drops by default
+	L2ForwardingTable = 32
+	// OutputTable - outputs the packets to the port selected in TunnelTable or L2ForwardingTable
+	OutputTable = 40
+)
+
+// outputPortRegister - register carrying the output port from TunnelTable to OutputTable
+const outputPortRegister = "NXM_NX_REG1[]"
+
+// SetOutputPortAction returns the action selecting the port the packet is sent to in OutputTable
+func SetOutputPortAction(portNo int) string {
+	return fmt.Sprintf("load:%d->%s", portNo, outputPortRegister)
+}
+
+// GotoTableAction returns the action passing the packet to the given table
+func GotoTableAction(table int) string {
+	return fmt.Sprintf("goto_table:%d", table)
+}
+
+// pipelineFlows returns the default flows of the pipeline tables
+func pipelineFlows() []string {
+	return []string{
+		fmt.Sprintf("table=%d,priority=0,actions=drop", ClassificationTable),
+		fmt.Sprintf("table=%d,priority=0,actions=drop", QinQTable),
+		fmt.Sprintf("table=%d,priority=0,actions=%s", SecurityTable, GotoTableAction(ConntrackTable)),
+		fmt.Sprintf("table=%d,priority=0,actions=%s", ConntrackTable, GotoTableAction(TunnelTable)),
+		fmt.Sprintf("table=%d,priority=0,actions=drop", ACLTable),
+		fmt.Sprintf("table=%d,priority=0,actions=drop", TunnelTable),
+		fmt.Sprintf("table=%d,priority=0,actions=drop", L2ForwardingTable),
+		fmt.Sprintf("table=%d,priority=0,actions=drop", OutputTable),
+		// no output port selected
+		fmt.Sprintf("table=%d,priority=100,reg1=0,actions=drop", OutputTable),
+		fmt.Sprintf("table=%d,priority=10,actions=output:%s", OutputTable, outputPortRegister),
+	}
+}
+
+// ConfigurePipeline installs the default flows of the pipeline tables on the bridge
+func ConfigurePipeline(bridgeName string) error {
+	for _, ofRule := range pipelineFlows() {
+		stdout, stderr, err := util.RunOVSOfctl("add-flow", "-OOpenflow13", bridgeName, ofRule)
+		if err != nil {
+			return errors.Wrapf(err, "failed to add pipeline flow %s on %s, stdout: %q, stderr: %q", ofRule, bridgeName, stdout, stderr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ServiceVlanIDKey - mechanism parameter key carrying the 802.1ad service (outer) VLAN ID
+	ServiceVlanIDKey = "svlan-id"
+	// QinQTable - openflow table matching the customer (inner) VLAN once the service VLAN is popped, it is
+	// part of the classification stage of the pipeline
+	QinQTable = 1
+)
+
+var (
+	doubleTaggingMutex   sync.Mutex
+	doubleTaggingEnabled bool
+)
+
+// EnableDoubleTagging allows ovs to parse both the 802.1ad service and the 802.1Q customer VLAN headers. It sets
+// other_config:vlan-limit=2 of Open_vSwitch, which changes the VLAN parsing of all the bridges of the node, not
+// only the NSM ones, so it is done once the first connection with a service VLAN is established.
+func EnableDoubleTagging() error {
+	doubleTaggingMutex.Lock()
+	defer doubleTaggingMutex.Unlock()
+	if doubleTaggingEnabled {
+		return nil
+	}
+	stdout, stderr, err := util.RunOVSVsctl("set", "Open_vSwitch", ".", "other_config:vlan-limit=2")
+	if err != nil {
+		return errors.Wrapf(err, "failed to set vlan-limit, stdout: %q, stderr: %q", stdout, stderr)
+	}
+	doubleTaggingEnabled = true
+	return nil
+}
+
+// GetServiceVlanID returns the service VLAN ID from the mechanism parameters, 0 when it is not set or invalid
+func GetServiceVlanID(parameters map[string]string) uint32 {
+	// vlan ID range is 0 to 4,095 stored in 12 bit
+	vlanID, err := strconv.ParseUint(parameters[ServiceVlanIDKey], 10, 12)
+	if err != nil {
+		return 0
+	}
+	return uint32(vlanID)
+}
+
+// QinQPushActions returns openflow actions pushing the customer VLAN (when cVlanID > 0) and
+// the 802.1ad service VLAN on top of it
+func QinQPushActions(sVlanID, cVlanID uint32) string {
+	var actions []string
+	if cVlanID > 0 {
+		actions = append(actions, fmt.Sprintf("push_vlan:0x8100,set_field:%d->vlan_vid", cVlanID+4096))
+	}
+	actions = append(actions, fmt.Sprintf("push_vlan:0x88a8,set_field:%d->vlan_vid", sVlanID+4096))
+	return strings.Join(actions, ",")
+}
+
+// QinQPopFlows returns openflow rules popping the service VLAN (and the customer VLAN when cVlanID > 0)
+// of the packets received on inPort before applying the given actions. The customer VLAN is matched
+// in QinQTable with the service VLAN carried in metadata.
+func QinQPopFlows(inPort int, sVlanID, cVlanID uint32, actions string) []string {
+	if cVlanID == 0 {
+		return []string{fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%d,actions=pop_vlan,%s", inPort, sVlanID, actions)}
+	}
+	return []string{
+		fmt.Sprintf("priority=100,in_port=%d,dl_vlan=%d,actions=pop_vlan,write_metadata:%d,goto_table:%d",
+			inPort, sVlanID, sVlanID, QinQTable),
+		fmt.Sprintf("table=%d,priority=100,in_port=%d,metadata=%d,dl_vlan=%d,actions=pop_vlan,%s",
+			QinQTable, inPort, sVlanID, cVlanID, actions),
+	}
+}
+
+// DeleteQinQPopFlows deletes the flows installed from QinQPopFlows. The service VLAN flow is shared between
+// customer VLANs of the same port, so it is only removed once no customer VLAN flow refers to it anymore.
+func DeleteQinQPopFlows(bridgeName string, inPort int, sVlanID, cVlanID uint32) error {
+	sVlanMatch := fmt.Sprintf("table=0,in_port=%d,dl_vlan=%d", inPort, sVlanID)
+	if cVlanID > 0 {
+		metadataMatch := fmt.Sprintf("table=%d,in_port=%d,metadata=%d", QinQTable, inPort, sVlanID)
+		stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", "--strict", bridgeName,
+			fmt.Sprintf("%s,dl_vlan=%d,priority=100", metadataMatch, cVlanID))
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete QinQ flow on %s, stdout: %q, stderr: %q", bridgeName, stdout, stderr)
+		}
+		stdout, stderr, err = util.RunOVSOfctl("dump-flows", "-OOpenflow13", "--no-stats", bridgeName, metadataMatch)
+		if err != nil {
+			return errors.Wrapf(err, "failed to dump QinQ flows on %s, stdout: %q, stderr: %q", bridgeName, stdout, stderr)
+		}
+		if strings.Contains(stdout, "actions=") {
+			return nil
+		}
+	}
+	stdout, stderr, err := util.RunOVSOfctl("del-flows", "-OOpenflow13", bridgeName, sVlanMatch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete QinQ flow on %s, stdout: %q, stderr: %q", bridgeName, stdout, stderr)
+	}
+	return nil
+}
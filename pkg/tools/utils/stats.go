@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// GetInterfaceStatistics returns the statistics column of the ovs interface, e.g. rx_packets, tx_bytes, rx_dropped
+func GetInterfaceStatistics(interfaceName string) (map[string]uint64, error) {
+	stdout, stderr, err := util.RunOVSVsctl("get", "interface", interfaceName, "statistics")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get statistics of interface %s, stderr: %q", interfaceName, stderr)
+	}
+	statistics := make(map[string]uint64)
+	for _, field := range strings.Split(strings.Trim(stdout, "{}"), ",") {
+		keyValue := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(keyValue) != 2 {
+			continue
+		}
+		value, parseErr := strconv.ParseUint(keyValue[1], 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		statistics[keyValue[0]] = value
+	}
+	return statistics, nil
+}
+
+// GetFlowStatistics sums the packet and byte counters of the flows matching ofMatch and outputting
+// the packets to a port, either directly, back to their ingress port or through the OutputTable or the
+// L2ForwardingTable of the pipeline
+func GetFlowStatistics(bridgeName, ofMatch string) (packets, bytes uint64, err error) {
+	stdout, stderr, err := util.RunOVSOfctl("dump-flows", "-OOpenflow13", bridgeName, ofMatch)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to dump flows on %s, stderr: %q", bridgeName, stderr)
+	}
+	for _, flow := range strings.Split(stdout, "\n") {
+		if !strings.Contains(flow, "output:") && !strings.Contains(flow, "IN_PORT") && !strings.Contains(flow, GotoTableAction(OutputTable)) &&
+			!strings.Contains(flow, GotoTableAction(L2ForwardingTable)) {
+			continue
+		}
+		packets += getFlowCounter(flow, "n_packets=")
+		bytes += getFlowCounter(flow, "n_bytes=")
+	}
+	return packets, bytes, nil
+}
+
+// GetFlowDropStatistics sums the packet counters of the flows matching ofMatch and dropping the packets
+func GetFlowDropStatistics(bridgeName, ofMatch string) (uint64, error) {
+	stdout, stderr, err := util.RunOVSOfctl("dump-flows", "-OOpenflow13", bridgeName, ofMatch)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to dump flows on %s, stderr: %q", bridgeName, stderr)
+	}
+	var packets uint64
+	for _, flow := range strings.Split(stdout, "\n") {
+		if strings.HasSuffix(strings.TrimSpace(flow), "actions=drop") {
+			packets += getFlowCounter(flow, "n_packets=")
+		}
+	}
+	return packets, nil
+}
+
+func getFlowCounter(flow, counter string) uint64 {
+	idx := strings.Index(flow, counter)
+	if idx < 0 {
+		return 0
+	}
+	value := flow[idx+len(counter):]
+	if end := strings.IndexAny(value, ", "); end >= 0 {
+		value = value[:end]
+	}
+	count, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return count
+}
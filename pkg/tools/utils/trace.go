@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// TraceStage is an openflow table a traced packet went through
+type TraceStage struct {
+	Table int    `json:"table"`
+	Name  string `json:"name,omitempty"`
+	// Match is the match of the flow hit by the packet, empty on table miss
+	Match    string   `json:"match,omitempty"`
+	Priority int      `json:"priority"`
+	Cookie   string   `json:"cookie,omitempty"`
+	Actions  []string `json:"actions,omitempty"`
+}
+
+// TraceResult is the result of ofproto/trace of a packet on a bridge
+type TraceResult struct {
+	Flow            string        `json:"flow"`
+	Stages          []*TraceStage `json:"stages"`
+	FinalFlow       string        `json:"finalFlow,omitempty"`
+	Megaflow        string        `json:"megaflow,omitempty"`
+	DatapathActions string        `json:"datapathActions"`
+	Dropped         bool          `json:"dropped"`
+	Output          string        `json:"output"`
+}
+
+// traceStageRegexp matches the first line of a stage, e.g. " 0. in_port=1, priority 100, cookie 0x2a"
+var traceStageRegexp = regexp.MustCompile(`^\s*(\d+)\.\s+(.*)$`)
+
+// tableNames are the names of the pipeline tables
+var tableNames = map[int]string{
+	ClassificationTable: "classification",
+	QinQTable:           "qinq",
+	SecurityTable:       "security",
+	ConntrackTable:      "conntrack",
+	ACLTable:            "acl",
+	TunnelTable:         "tunnel",
+	L2LearningTable:     "l2-learning",
+	L2ForwardingTable:   "l2-forwarding",
+	OutputTable:         "output",
+}
+
+// Trace runs ofproto/trace of the packet described by flow, e.g. "in_port=1,icmp,nw_src=10.0.0.1,nw_dst=10.0.0.2",
+// on the bridge and returns the parsed result
+func Trace(bridgeName, flow string) (*TraceResult, error) {
+	stdout, stderr, err := util.RunOVSAppctl("ofproto/trace", bridgeName, flow)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to trace %s on %s, stdout: %q, stderr: %q", flow, bridgeName, stdout, stderr)
+	}
+	return ParseTrace(stdout), nil
+}
+
+// ParseTrace parses the output of ofproto/trace
+func ParseTrace(output string) *TraceResult {
+	result := &TraceResult{Output: output}
+	var stage *TraceStage
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "Flow: "):
+			result.Flow = strings.TrimPrefix(trimmed, "Flow: ")
+		case strings.HasPrefix(trimmed, "Final flow: "):
+			result.FinalFlow = strings.TrimPrefix(trimmed, "Final flow: ")
+			stage = nil
+		case strings.HasPrefix(trimmed, "Megaflow: "):
+			result.Megaflow = strings.TrimPrefix(trimmed, "Megaflow: ")
+		case strings.HasPrefix(trimmed, "Datapath actions: "):
+			result.DatapathActions = strings.TrimPrefix(trimmed, "Datapath actions: ")
+		case traceStageRegexp.MatchString(line):
+			stage = parseTraceStage(traceStageRegexp.FindStringSubmatch(line))
+			result.Stages = append(result.Stages, stage)
+		case stage != nil && trimmed != "" && !strings.HasPrefix(trimmed, "-"):
+			stage.Actions = append(stage.Actions, trimmed)
+		}
+	}
+	result.Dropped = result.DatapathActions == "" || result.DatapathActions == "drop"
+	return result
+}
+
+func parseTraceStage(submatch []string) *TraceStage {
+	table, _ := strconv.Atoi(submatch[1])
+	stage := &TraceStage{Table: table, Name: tableNames[table]}
+	for _, field := range strings.Split(submatch[2], ", ") {
+		switch {
+		case strings.HasPrefix(field, "priority "):
+			stage.Priority, _ = strconv.Atoi(strings.TrimPrefix(field, "priority "))
+		case strings.HasPrefix(field, "cookie "):
+			stage.Cookie = strings.TrimPrefix(field, "cookie ")
+		case field == "No match.":
+		default:
+			stage.Match = field
+		}
+	}
+	return stage
+}
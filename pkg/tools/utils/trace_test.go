@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const forwardedTrace = `Flow: icmp,in_port=1,vlan_tci=0x0000,dl_src=02:00:00:00:00:01,dl_dst=02:00:00:00:00:02,nw_src=10.0.0.1,nw_dst=10.0.0.2
+
+bridge("br-nsm")
+----------------
+ 0. in_port=1, priority 100, cookie 0x2a
+    goto_table:10
+10. priority 0
+    goto_table:20
+20. priority 0
+    goto_table:30
+30. in_port=1, priority 100
+    load:0x2->NXM_NX_REG1[]
+    goto_table:40
+40. reg1=0x2, priority 100
+    output:2
+
+Final flow: unchanged
+Megaflow: recirc_id=0,eth,icmp,in_port=1,nw_frag=no
+Datapath actions: 3
+`
+
+const droppedTrace = `Flow: tcp,in_port=1,vlan_tci=0x0000,nw_src=10.0.0.1,nw_dst=10.0.0.2,tp_dst=22
+
+bridge("br-nsm")
+----------------
+ 0. in_port=1, priority 100
+    goto_table:10
+10. priority 0
+    goto_table:20
+20. tcp,in_port=1, priority 100
+    ct(table=21,zone=1)
+    drop
+     -> A clone of the packet is forked to recirculate. The forked pipeline will be resumed at table 21.
+
+Final flow: unchanged
+Megaflow: recirc_id=0,eth,tcp,in_port=1,nw_frag=no
+Datapath actions: ct(zone=1),recirc(0x1)
+
+===============================================================================
+recirc(0x1) - resume conntrack with ct_state=new|trk
+===============================================================================
+
+Flow: recirc_id=0x1,ct_state=new|trk,ct_zone=1,eth,tcp,in_port=1,nw_src=10.0.0.1,nw_dst=10.0.0.2,tp_dst=22
+
+bridge("br-nsm")
+----------------
+    thaw
+        Resuming from table 21
+21. in_port=1, priority 100
+    drop
+
+Final flow: unchanged
+Megaflow: recirc_id=0x1,ct_state=+new-est-rel-inv+trk,eth,ip,in_port=1,nw_frag=no
+Datapath actions: drop
+`
+
+func TestParseTrace(t *testing.T) {
+	for _, sample := range []struct {
+		name     string
+		output   string
+		expected *TraceResult
+	}{
+		{
+			name:   "forwarded",
+			output: forwardedTrace,
+			expected: &TraceResult{
+				Flow: "icmp,in_port=1,vlan_tci=0x0000,dl_src=02:00:00:00:00:01,dl_dst=02:00:00:00:00:02,nw_src=10.0.0.1,nw_dst=10.0.0.2",
+				Stages: []*TraceStage{
+					{Table: ClassificationTable, Name: "classification", Match: "in_port=1", Priority: 100, Cookie: "0x2a",
+						Actions: []string{"goto_table:10"}},
+					{Table: SecurityTable, Name: "security", Actions: []string{"goto_table:20"}},
+					{Table: ConntrackTable, Name: "conntrack", Actions: []string{"goto_table:30"}},
+					{Table: TunnelTable, Name: "tunnel", Match: "in_port=1", Priority: 100,
+						Actions: []string{"load:0x2->NXM_NX_REG1[]", "goto_table:40"}},
+					{Table: OutputTable, Name: "output", Match: "reg1=0x2", Priority: 100, Actions: []string{"output:2"}},
+				},
+				FinalFlow:       "unchanged",
+				Megaflow:        "recirc_id=0,eth,icmp,in_port=1,nw_frag=no",
+				DatapathActions: "3",
+				Output:          forwardedTrace,
+			},
+		},
+		{
+			name:   "dropped after conntrack recirculation",
+			output: droppedTrace,
+			expected: &TraceResult{
+				// the packet is described by the last flow traced
+				Flow: "recirc_id=0x1,ct_state=new|trk,ct_zone=1,eth,tcp,in_port=1,nw_src=10.0.0.1,nw_dst=10.0.0.2,tp_dst=22",
+				Stages: []*TraceStage{
+					{Table: ClassificationTable, Name: "classification", Match: "in_port=1", Priority: 100,
+						Actions: []string{"goto_table:10"}},
+					{Table: SecurityTable, Name: "security", Actions: []string{"goto_table:20"}},
+					{Table: ConntrackTable, Name: "conntrack", Match: "tcp,in_port=1", Priority: 100,
+						Actions: []string{"ct(table=21,zone=1)", "drop"}},
+					{Table: ACLTable, Name: "acl", Match: "in_port=1", Priority: 100, Actions: []string{"drop"}},
+				},
+				FinalFlow:       "unchanged",
+				Megaflow:        "recirc_id=0x1,ct_state=+new-est-rel-inv+trk,eth,ip,in_port=1,nw_frag=no",
+				DatapathActions: "drop",
+				Dropped:         true,
+				Output:          droppedTrace,
+			},
+		},
+		{
+			name:   "table miss",
+			output: "Flow: in_port=9\n\nbridge(\"br-nsm\")\n----------------\n 0. No match.\n    drop\n\nFinal flow: unchanged\n",
+			expected: &TraceResult{
+				Flow:      "in_port=9",
+				Stages:    []*TraceStage{{Table: ClassificationTable, Name: "classification", Actions: []string{"drop"}}},
+				FinalFlow: "unchanged",
+				Dropped:   true,
+				Output:    "Flow: in_port=9\n\nbridge(\"br-nsm\")\n----------------\n 0. No match.\n    drop\n\nFinal flow: unchanged\n",
+			},
+		},
+	} {
+		t.Run(sample.name, func(t *testing.T) {
+			require.Equal(t, sample.expected, ParseTrace(sample.output))
+		})
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright (c) 2026 Nordix Foundation.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+const (
+	uplinkStateFileSuffix = ".json"
+	uplinkStateFileMode   = 0o600
+	uplinkStateDirMode    = 0o700
+)
+
+// uplinkState is the original network configuration of an uplink interface taken over by an l2 bridge
+type uplinkState struct {
+	Link   string         `json:"link"`
+	Port   string         `json:"port"`
+	Bridge string         `json:"bridge"`
+	Addrs  []uplinkAddr   `json:"addrs"`
+	Routes []uplinkRoute  `json:"routes"`
+	Rules  []netlink.Rule `json:"rules"`
+}
+
+type uplinkAddr struct {
+	IPNet *net.IPNet `json:"ipNet"`
+	Scope int        `json:"scope"`
+	Flags int        `json:"flags"`
+}
+
+type uplinkRoute struct {
+	Family   int                   `json:"family"`
+	Dst      *net.IPNet            `json:"dst"`
+	Src      net.IP                `json:"src"`
+	Gw       net.IP                `json:"gw"`
+	Table    int                   `json:"table"`
+	Priority int                   `json:"priority"`
+	Scope    netlink.Scope         `json:"scope"`
+	Protocol netlink.RouteProtocol `json:"protocol"`
+	Type     int                   `json:"type"`
+}
+
+// takeOverUplinks moves the addresses, routes and policy rules of the uplink links to the bridge while the port
+// of the uplinks is added to the bridge by addPort. The original configuration is persisted in stateDir beforehand.
+// If the state of a link is already persisted, the link was taken over by a previous run which was not restored,
+// then the persisted configuration is applied on the bridge.
+func takeOverUplinks(ctx context.Context, stateDir string, linkNames []string, portName, bridgeName string, addPort func() error) error {
+	var states []*uplinkState
+	for _, linkName := range linkNames {
+		logger := log.FromContext(ctx).WithField("uplink", linkName)
+		state, err := loadUplinkState(stateDir, linkName)
+		if err != nil {
+			return err
+		}
+		if state != nil {
+			logger.Infof("uplink was not restored by a previous run, reapplying its configuration on %s", bridgeName)
+			states = append(states, state)
+			continue
+		}
+		if state, err = getUplinkState(linkName, portName, bridgeName); err != nil {
+			return err
+		}
+		// the uplink is not touched if its original configuration can't be restored later
+		if err = saveUplinkState(stateDir, state); err != nil {
+			logger.Errorf("failed to persist the original configuration: %v", err)
+			return err
+		}
+		if err = removeUplinkConfig(linkName, state); err != nil {
+			return err
+		}
+		states = append(states, state)
+	}
+	if err := addPort(); err != nil {
+		return err
+	}
+	for _, state := range states {
+		if err := applyUplinkConfig(bridgeName, state.Link, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreUplinks restores the original configuration of the uplinks taken over by the l2 bridges from the states
+// persisted in stateDir: the uplink is removed from the bridge and gets back its addresses, routes and policy rules.
+// Nothing is restored when stateDir is empty.
+func RestoreUplinks(ctx context.Context, stateDir string) error {
+	if stateDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(stateDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to read uplink state dir %s", stateDir)
+	}
+	var restoreErrs []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), uplinkStateFileSuffix) {
+			continue
+		}
+		linkName := strings.TrimSuffix(entry.Name(), uplinkStateFileSuffix)
+		if restoreErr := restoreUplink(ctx, stateDir, linkName); restoreErr != nil {
+			restoreErrs = append(restoreErrs, restoreErr.Error())
+		}
+	}
+	if len(restoreErrs) > 0 {
+		return errors.Errorf("failed to restore uplinks: %s", strings.Join(restoreErrs, "; "))
+	}
+	return nil
+}
+
+func restoreUplink(ctx context.Context, stateDir, linkName string) error {
+	state, err := loadUplinkState(stateDir, linkName)
+	if err != nil || state == nil {
+		return err
+	}
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "del-port", state.Bridge, state.Port)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete uplink port %s from %s, stdout: %q, stderr: %q", state.Port, state.Bridge, stdout, stderr)
+	}
+	if err = removeUplinkConfig(state.Bridge, state); err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find link %s", linkName)
+	}
+	if err = netlink.LinkSetUp(link); err != nil {
+		return errors.Wrapf(err, "failed to set link %s up", linkName)
+	}
+	if err = applyUplinkConfig(linkName, state.Bridge, state); err != nil {
+		return err
+	}
+	if err = os.Remove(uplinkStateFile(stateDir, linkName)); err != nil {
+		return errors.Wrapf(err, "failed to remove uplink state of %s", linkName)
+	}
+	log.FromContext(ctx).WithField("uplink", linkName).Infof("restored original configuration from %s", state.Bridge)
+	return nil
+}
+
+func getUplinkState(linkName, portName, bridgeName string) (*uplinkState, error) {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find link %s", linkName)
+	}
+	state := &uplinkState{Link: linkName, Port: portName, Bridge: bridgeName}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get a list of IP addresses")
+	}
+	for idx := range addrs {
+		state.Addrs = append(state.Addrs, uplinkAddr{IPNet: addrs[idx].IPNet, Scope: addrs[idx].Scope, Flags: addrs[idx].Flags})
+	}
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{LinkIndex: link.Attrs().Index},
+		netlink.RT_FILTER_OIF|netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get a list of routes")
+	}
+	for idx := range routes {
+		route := &routes[idx]
+		// the kernel routes of the addresses are created along with the addresses
+		if route.Protocol == syscall.RTPROT_KERNEL || route.Table == syscall.RT_TABLE_LOCAL {
+			continue
+		}
+		state.Routes = append(state.Routes, uplinkRoute{
+			Family:   route.Family,
+			Dst:      route.Dst,
+			Src:      route.Src,
+			Gw:       route.Gw,
+			Table:    route.Table,
+			Priority: route.Priority,
+			Scope:    route.Scope,
+			Protocol: route.Protocol,
+			Type:     route.Type,
+		})
+	}
+	rules, err := netlink.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get a list of rules")
+	}
+	for idx := range rules {
+		if rules[idx].IifName == linkName || rules[idx].OifName == linkName {
+			state.Rules = append(state.Rules, rules[idx])
+		}
+	}
+	return state, nil
+}
+
+// removeUplinkConfig removes the configuration of the state from the link
+func removeUplinkConfig(linkName string, state *uplinkState) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find link %s", linkName)
+	}
+	for idx := range state.Routes {
+		route := state.Routes[idx].toRoute(link.Attrs().Index)
+		if err = netlink.RouteDel(route); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return errors.Wrapf(err, "failed to delete route %s", route.String())
+		}
+	}
+	for idx := range state.Rules {
+		rule := moveRule(state.Rules[idx], state.Link, linkName)
+		if err = netlink.RuleDel(&rule); err != nil && !errors.Is(err, syscall.ENOENT) {
+			return errors.Wrapf(err, "failed to delete rule %s", rule.String())
+		}
+	}
+	for idx := range state.Addrs {
+		if err = netlink.AddrDel(link, state.Addrs[idx].toAddr()); err != nil && !errors.Is(err, syscall.EADDRNOTAVAIL) {
+			return errors.Wrapf(err, "failed to delete IP address from link device")
+		}
+	}
+	return nil
+}
+
+// applyUplinkConfig applies the configuration of the state on the link, the rules of originalLinkName are
+// moved to the link
+func applyUplinkConfig(linkName, originalLinkName string, state *uplinkState) error {
+	link, err := netlink.LinkByName(linkName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find link %s", linkName)
+	}
+	if err = netlink.LinkSetUp(link); err != nil {
+		return errors.Wrapf(err, "failed to set link %s up", linkName)
+	}
+	for idx := range state.Addrs {
+		if err = netlink.AddrAdd(link, state.Addrs[idx].toAddr()); err != nil && !errors.Is(err, syscall.EEXIST) {
+			return errors.Wrapf(err, "failed to add IP address from link device")
+		}
+	}
+	// the link scope routes go first, as the gateways of the other routes are resolved through them
+	routes := append([]uplinkRoute(nil), state.Routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Scope > routes[j].Scope
+	})
+	for idx := range routes {
+		route := routes[idx].toRoute(link.Attrs().Index)
+		if err = netlink.RouteAdd(route); err != nil && !errors.Is(err, syscall.EEXIST) {
+			return errors.Wrapf(err, "failed to add route %s", route.String())
+		}
+	}
+	for idx := range state.Rules {
+		rule := moveRule(state.Rules[idx], originalLinkName, linkName)
+		if err = netlink.RuleAdd(&rule); err != nil && !errors.Is(err, syscall.EEXIST) {
+			return errors.Wrapf(err, "failed to add rule %s", rule.String())
+		}
+	}
+	return nil
+}
+
+// moveRule returns the rule with its input and output interfaces renamed from fromLink to toLink
+func moveRule(rule netlink.Rule, fromLink, toLink string) netlink.Rule {
+	if rule.IifName == fromLink {
+		rule.IifName = toLink
+	}
+	if rule.OifName == fromLink {
+		rule.OifName = toLink
+	}
+	return rule
+}
+
+func (a *uplinkAddr) toAddr() *netlink.Addr {
+	return &netlink.Addr{IPNet: a.IPNet, Scope: a.Scope, Flags: a.Flags}
+}
+
+func (r *uplinkRoute) toRoute(linkIndex int) *netlink.Route {
+	return &netlink.Route{
+		LinkIndex: linkIndex,
+		Family:    r.Family,
+		Dst:       r.Dst,
+		Src:       r.Src,
+		Gw:        r.Gw,
+		Table:     r.Table,
+		Priority:  r.Priority,
+		Scope:     r.Scope,
+		Protocol:  r.Protocol,
+		Type:      r.Type,
+	}
+}
+
+func uplinkStateFile(stateDir, linkName string) string {
+	return filepath.Join(stateDir, linkName+uplinkStateFileSuffix)
+}
+
+// loadUplinkState returns the persisted state of the link, nil if there is none
+func loadUplinkState(stateDir, linkName string) (*uplinkState, error) {
+	if stateDir == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(uplinkStateFile(stateDir, linkName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read uplink state of %s", linkName)
+	}
+	state := &uplinkState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal uplink state of %s", linkName)
+	}
+	return state, nil
+}
+
+func saveUplinkState(stateDir string, state *uplinkState) error {
+	if stateDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(stateDir, uplinkStateDirMode); err != nil {
+		return errors.Wrapf(err, "failed to create uplink state dir %s", stateDir)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal uplink state of %s", state.Link)
+	}
+	// write and rename, so that a crash never leaves a partial state behind
+	tmpFile := uplinkStateFile(stateDir, state.Link) + ".tmp"
+	if err = os.WriteFile(tmpFile, data, uplinkStateFileMode); err != nil {
+		return errors.Wrapf(err, "failed to write uplink state of %s", state.Link)
+	}
+	return errors.Wrapf(os.Rename(tmpFile, uplinkStateFile(stateDir, state.Link)), "failed to write uplink state of %s", state.Link)
+}